@@ -6,6 +6,7 @@ import (
 
 	"github.com/andybalholm/brotli"
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 type Brotli struct {
@@ -32,7 +33,7 @@ func (c Brotli) DecompressBlock(block []byte) ([]byte, error) {
 
 	ret, err := ioutil.ReadAll(r)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decompress Brotli data")
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to decompress Brotli data"))
 	}
 
 	return ret, nil
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 type GZip struct {
@@ -31,12 +32,12 @@ func (c GZip) DecompressBlock(block []byte) ([]byte, error) {
 
 	r, err := gzip.NewReader(buf)
 	if err != nil {
-		return nil, err
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "invalid GZIP header"))
 	}
 
 	ret, err := ioutil.ReadAll(r)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decompress GZIP data")
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to decompress GZIP data"))
 	}
 
 	return ret, r.Close()
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/pierrec/lz4"
 )
 
@@ -32,7 +33,7 @@ func (c LZ4) DecompressBlock(block []byte) ([]byte, error) {
 
 	ret, err := ioutil.ReadAll(r)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decompress LZ4 data")
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to decompress LZ4 data"))
 	}
 
 	return ret, nil
@@ -1,6 +1,10 @@
 package compression
 
-import "github.com/golang/snappy"
+import (
+	"github.com/golang/snappy"
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
+)
 
 type Snappy struct {
 }
@@ -10,5 +14,10 @@ func (c Snappy) CompressBlock(block []byte) ([]byte, error) {
 }
 
 func (c Snappy) DecompressBlock(block []byte) ([]byte, error) {
-	return snappy.Decode(nil, block)
+	ret, err := snappy.Decode(nil, block)
+	if err != nil {
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to decompress Snappy data"))
+	}
+
+	return ret, nil
 }
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/klauspost/compress/zstd"
 )
 
@@ -40,7 +41,7 @@ func (c ZStd) DecompressBlock(block []byte) ([]byte, error) {
 
 	ret, err := ioutil.ReadAll(r)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decompress ZSTD data")
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to decompress ZSTD data"))
 	}
 
 	return ret, nil
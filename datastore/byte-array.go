@@ -1,6 +1,8 @@
 package datastore
 
 import (
+	"bytes"
+
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/parquet"
 )
@@ -84,6 +86,10 @@ func (s *ByteArrayStore) GetValues(v interface{}) ([]interface{}, error) { //nol
 
 	switch typed := v.(type) {
 	case []byte:
+		if err := s.setMinMax(typed); err != nil {
+			return nil, err
+		}
+
 		values = []interface{}{typed}
 
 	case [][]byte:
@@ -94,6 +100,10 @@ func (s *ByteArrayStore) GetValues(v interface{}) ([]interface{}, error) { //nol
 		values = make([]interface{}, len(typed))
 
 		for j := range typed {
+			if err := s.setMinMax(typed[j]); err != nil {
+				return nil, err
+			}
+
 			values[j] = typed[j]
 		}
 	default:
@@ -114,3 +124,90 @@ func (s *ByteArrayStore) Append(arrayIn, value interface{}) interface{} {
 
 	return append(arrayIn.([][]byte), value.([]byte))
 }
+
+func (s *ByteArrayStore) setMinMax(n []byte) error {
+	if s.TypeLength != nil && *s.TypeLength > 0 && int32(len(n)) != *s.TypeLength {
+		return errors.WithFields(
+			errors.New("invalid data size"),
+			errors.Fields{
+				"expected": *s.TypeLength,
+				"actual":   len(n),
+			})
+	}
+
+	// For nil value there is no need to set the min/max
+	if n == nil {
+		return nil
+	}
+
+	if s.max == nil || s.min == nil {
+		s.min = n
+		s.max = n
+
+		return nil
+	}
+
+	if s.compare(n, s.min) < 0 {
+		s.min = n
+	}
+
+	if s.compare(n, s.max) > 0 {
+		s.max = n
+	}
+
+	return nil
+}
+
+// compare orders the values as signed big-endian integers for the decimals,
+// and as unsigned bytes otherwise.
+func (s *ByteArrayStore) compare(a, b []byte) int {
+	if s.isDecimal() {
+		return compareTwosComplement(a, b)
+	}
+
+	return bytes.Compare(a, b)
+}
+
+func (s *ByteArrayStore) isDecimal() bool {
+	if s.ColumnParameters == nil {
+		return false
+	}
+
+	if s.LogicalType != nil && s.LogicalType.IsSetDECIMAL() {
+		return true
+	}
+
+	return s.ConvertedType != nil && *s.ConvertedType == parquet.ConvertedType_DECIMAL
+}
+
+// compareTwosComplement compares two big-endian two's complement integers, of any size.
+func compareTwosComplement(a, b []byte) int {
+	negA := len(a) > 0 && a[0]&0x80 != 0
+	negB := len(b) > 0 && b[0]&0x80 != 0
+
+	switch {
+	case negA && !negB:
+		return -1
+	case !negA && negB:
+		return 1
+	}
+
+	// Same sign: sign-extend the shortest and compare the bytes.
+	var ext byte
+	if negA {
+		ext = 0xff
+	}
+
+	return bytes.Compare(signExtend(a, len(b), ext), signExtend(b, len(a), ext))
+}
+
+func signExtend(b []byte, size int, ext byte) []byte {
+	if len(b) >= size {
+		return b
+	}
+
+	ret := bytes.Repeat([]byte{ext}, size)
+	copy(ret[size-len(b):], b)
+
+	return ret
+}
@@ -0,0 +1,85 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteArrayStore_MinMax(t *testing.T) {
+	t.Run("Bytes", TestByteArrayStore_MinMax_Bytes)
+	t.Run("Decimal", TestByteArrayStore_MinMax_Decimal)
+	t.Run("VariableSizeDecimal", TestByteArrayStore_MinMax_VariableSizeDecimal)
+	t.Run("InvalidSize", TestByteArrayStore_MinMax_InvalidSize)
+}
+
+func TestByteArrayStore_MinMax_Bytes(t *testing.T) {
+	t.Parallel()
+
+	length := int32(2)
+	s, err := NewFixedByteArrayStore(parquet.Encoding_PLAIN, true, &ColumnParameters{TypeLength: &length})
+	require.NoError(t, err)
+	require.NoError(t, s.Reset(parquet.FieldRepetitionType_REQUIRED, 0, 0))
+
+	for _, v := range [][]byte{{0x00, 0x01}, {0xff, 0x00}, {0x7f, 0xff}} {
+		_, err := s.GetValues(v)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []byte{0x00, 0x01}, s.MinValue())
+	assert.Equal(t, []byte{0xff, 0x00}, s.MaxValue())
+}
+
+func TestByteArrayStore_MinMax_Decimal(t *testing.T) {
+	t.Parallel()
+
+	length := int32(2)
+	s, err := NewFixedByteArrayStore(parquet.Encoding_PLAIN, true, &ColumnParameters{
+		TypeLength:    &length,
+		ConvertedType: parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL),
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Reset(parquet.FieldRepetitionType_REQUIRED, 0, 0))
+
+	// 1, -256, 32767, -1
+	for _, v := range [][]byte{{0x00, 0x01}, {0xff, 0x00}, {0x7f, 0xff}, {0xff, 0xff}} {
+		_, err := s.GetValues(v)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []byte{0xff, 0x00}, s.MinValue())
+	assert.Equal(t, []byte{0x7f, 0xff}, s.MaxValue())
+}
+
+func TestByteArrayStore_MinMax_VariableSizeDecimal(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewByteArrayStore(parquet.Encoding_PLAIN, true, &ColumnParameters{
+		LogicalType: &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: 10, Scale: 2}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Reset(parquet.FieldRepetitionType_REQUIRED, 0, 0))
+
+	// 127, -1, 256, -129
+	for _, v := range [][]byte{{0x7f}, {0xff}, {0x01, 0x00}, {0xff, 0x7f}} {
+		_, err := s.GetValues(v)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []byte{0xff, 0x7f}, s.MinValue())
+	assert.Equal(t, []byte{0x01, 0x00}, s.MaxValue())
+}
+
+func TestByteArrayStore_MinMax_InvalidSize(t *testing.T) {
+	t.Parallel()
+
+	length := int32(2)
+	s, err := NewFixedByteArrayStore(parquet.Encoding_PLAIN, true, &ColumnParameters{TypeLength: &length})
+	require.NoError(t, err)
+	require.NoError(t, s.Reset(parquet.FieldRepetitionType_REQUIRED, 0, 0))
+
+	_, err = s.GetValues([]byte{0x00})
+	assert.Error(t, err)
+}
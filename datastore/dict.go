@@ -1,33 +1,70 @@
 package datastore
 
 import (
-	"hash/fnv"
+	"bytes"
+	"hash/maphash"
+	"math"
 
 	"github.com/hexbee-net/errors"
 )
 
+// ErrDictionaryFull is returned by DictStore.AddValue and DictStore.AddValues when new
+// values would make the dictionary larger than its maximum size. The column should then
+// use another encoding.
+const ErrDictionaryFull = errors.Error("dictionary is full")
+
+const (
+	// arenaBlockSize is the size of the blocks holding the byte array values of a dictionary.
+	arenaBlockSize = 64 << 10
+	// arenaMaxValueSize is the size above which a byte array value gets its own allocation.
+	arenaMaxValueSize = arenaBlockSize / 4
+
+	noEntry int32 = -1
+
+	int96Size = 12
+	// byteArrayLenSize is the size of the length prefix of the PLAIN encoded byte arrays.
+	byteArrayLenSize = 4
+)
+
 type DictStore struct {
 	Values     []interface{}
 	Data       []int32
-	indices    map[interface{}]int32
 	size       int64
 	valueSize  int64
 	readPos    int
 	nullCount  int32
 	NoDictMode bool
 
-	hashFunc func([]byte) interface{}
+	// MaxSize is the maximum size in bytes of the PLAIN encoded distinct values of the
+	// dictionary, which is the size of the dictionary page before compression, 0 for no limit.
+	MaxSize int64
+
+	// indices are the entries of the comparable values.
+	indices map[interface{}]int32
+	// buckets are the first entries of the byte array values with a given hash, and
+	// next the following entries with the same hash, -1 for the last one.
+	buckets map[uint64]int32
+	next    []int32
+	arena   byteArena
+	seed    maphash.Seed
+
+	hashFunc func([]byte) uint64
 }
 
 func (s *DictStore) Init() {
 	s.Values = s.Values[:0]
 	s.Data = s.Data[:0]
 	s.indices = make(map[interface{}]int32)
+	s.buckets = make(map[uint64]int32)
+	s.next = s.next[:0]
+	s.arena = byteArena{} // the values of the previous dictionary may still be in use
 	s.size = 0
+	s.valueSize = 0
 	s.readPos = 0
 	s.nullCount = 0
 
-	s.hashFunc = fnvHashFunc
+	s.seed = maphash.MakeSeed()
+	s.hashFunc = s.maphashFunc
 }
 
 func (s *DictStore) GetNextValue() (interface{}, error) {
@@ -52,54 +89,229 @@ func (s *DictStore) GetNextValue() (interface{}, error) {
 	return s.Values[pos], nil
 }
 
-func (s *DictStore) AddValue(v interface{}, size int) {
+// AddValue adds a value to the dictionary. It returns ErrDictionaryFull, without adding
+// the value, if the value is not in the dictionary yet and would make it exceed MaxSize.
+// The size of the value is only used to estimate the size of the column data, the size
+// of the dictionary is the one of its PLAIN encoded values.
+func (s *DictStore) AddValue(v interface{}, size int) error {
 	if v == nil {
 		s.nullCount++
-		return
+		return nil
+	}
+
+	idx, err := s.getIndex(v)
+	if err != nil {
+		return err
 	}
 
 	s.size += int64(size)
-	s.Data = append(s.Data, s.getIndex(v, size))
+	s.Data = append(s.Data, idx)
+
+	return nil
 }
 
-func (s *DictStore) NumValues() int32 {
-	return int32(len(s.Data))
+// AddValues adds all the values to the dictionary, or none: it returns ErrDictionaryFull,
+// without adding any value, if the values not in the dictionary yet would make it exceed MaxSize.
+func (s *DictStore) AddValues(values []interface{}) error {
+	if err := s.reserveValues(values); err != nil {
+		return err
+	}
+
+	for _, v := range values {
+		if err := s.AddValue(v, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (s *DictStore) getIndex(in interface{}, size int) int32 {
-	key := s.mapKey(in)
+// reserveValues checks that the distinct values not in the dictionary yet can be added to it.
+func (s *DictStore) reserveValues(values []interface{}) error {
+	var (
+		count     int
+		size      int64
+		seen      = make(map[interface{}]bool)
+		seenBytes = make(map[string]bool)
+	)
+
+	for _, v := range values {
+		switch b := v.(type) {
+		case nil:
+			continue
+		case []byte:
+			if _, ok := s.findBytes(b, s.hashFunc(b)); ok || seenBytes[string(b)] {
+				continue
+			}
+
+			seenBytes[string(b)] = true
+		default:
+			if _, ok := s.indices[v]; ok || seen[v] {
+				continue
+			}
 
-	if idx, ok := s.indices[key]; ok {
-		return idx
+			seen[v] = true
+		}
+
+		count++
+		size += int64(valueSize(v))
 	}
 
-	s.valueSize += int64(size)
-	s.Values = append(s.Values, in)
-	idx := int32(len(s.Values) - 1)
+	return s.reserve(count, size)
+}
 
-	s.indices[key] = idx
+func (s *DictStore) NumValues() int32 {
+	return int32(len(s.Data))
+}
 
-	return idx
+// DictionarySize returns the size in bytes of the PLAIN encoded distinct values of the dictionary.
+func (s *DictStore) DictionarySize() int64 {
+	return s.valueSize
 }
 
-func (s *DictStore) mapKey(in interface{}) interface{} {
+func (s *DictStore) getIndex(in interface{}) (int32, error) {
 	switch v := in.(type) {
-	case int, int32, int64, string, bool, float64, float32:
-		return in
 	case []byte:
-		return s.hashFunc(v)
-	case [12]byte:
-		return s.hashFunc(v[:])
+		return s.getBytesIndex(v)
+	case int, int32, int64, string, bool, float64, float32, [int96Size]byte:
+		if idx, ok := s.indices[in]; ok {
+			return idx, nil
+		}
+
+		idx, err := s.add(in, valueSize(in))
+		if err != nil {
+			return 0, err
+		}
+
+		s.indices[in] = idx
+
+		return idx, nil
 	default:
 		panic("not supported type")
 	}
 }
 
-func fnvHashFunc(in []byte) interface{} {
-	hash := fnv.New64()
-	if err := writeFull(hash, in); err != nil {
+// getBytesIndex looks for a byte array value among the values with the same hash, and
+// copies it in the arena if it is a new value.
+func (s *DictStore) getBytesIndex(v []byte) (int32, error) {
+	key := s.hashFunc(v)
+
+	if idx, ok := s.findBytes(v, key); ok {
+		return idx, nil
+	}
+
+	if err := s.reserve(1, int64(valueSize(v))); err != nil {
+		return 0, err
+	}
+
+	idx, err := s.add(s.arena.copy(v), valueSize(v))
+	if err != nil {
+		return 0, err
+	}
+
+	head, ok := s.buckets[key]
+	if !ok {
+		head = noEntry
+	}
+
+	s.next[idx] = head
+	s.buckets[key] = idx
+
+	return idx, nil
+}
+
+// findBytes returns the entry of a byte array value with the given hash, if any.
+func (s *DictStore) findBytes(v []byte, key uint64) (int32, bool) {
+	head, ok := s.buckets[key]
+	if !ok {
+		return 0, false
+	}
+
+	for idx := head; idx != noEntry; idx = s.next[idx] {
+		if bytes.Equal(s.Values[idx].([]byte), v) {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+// reserve checks that count new values of the given total size can be added to the dictionary.
+func (s *DictStore) reserve(count int, size int64) error {
+	if len(s.Values) > math.MaxInt32-count || s.MaxSize > 0 && s.valueSize+size > s.MaxSize {
+		return errors.WithFields(
+			errors.WithStack(ErrDictionaryFull),
+			errors.Fields{
+				"max-size": s.MaxSize,
+				"size":     s.valueSize,
+				"entries":  len(s.Values),
+			})
+	}
+
+	return nil
+}
+
+func (s *DictStore) add(v interface{}, size int) (int32, error) {
+	if err := s.reserve(1, int64(size)); err != nil {
+		return 0, err
+	}
+
+	s.valueSize += int64(size)
+	s.Values = append(s.Values, v)
+	s.next = append(s.next, noEntry)
+
+	return int32(len(s.Values) - 1), nil
+}
+
+func (s *DictStore) maphashFunc(in []byte) uint64 {
+	var hash maphash.Hash
+
+	hash.SetSeed(s.seed)
+
+	if err := writeFull(&hash, in); err != nil {
 		panic(err)
 	}
 
 	return hash.Sum64()
 }
+
+// valueSize returns the size in bytes of a PLAIN encoded dictionary value, a byte for the booleans.
+func valueSize(v interface{}) int {
+	switch v := v.(type) {
+	case bool:
+		return 1
+	case int32, float32:
+		return 4 //nolint:gomnd // 32 bits
+	case int, int64, float64:
+		return 8 //nolint:gomnd // 64 bits
+	case string:
+		return byteArrayLenSize + len(v)
+	case []byte:
+		return byteArrayLenSize + len(v)
+	case [int96Size]byte:
+		return int96Size
+	default:
+		return 0
+	}
+}
+
+// byteArena stores the byte array values of a dictionary in large blocks, instead of
+// one allocation per value. The stored values are never moved or overwritten.
+type byteArena struct {
+	block []byte
+}
+
+func (a *byteArena) copy(v []byte) []byte {
+	if len(v) > arenaMaxValueSize {
+		return append([]byte(nil), v...)
+	}
+
+	if cap(a.block)-len(a.block) < len(v) {
+		a.block = make([]byte, 0, arenaBlockSize)
+	}
+
+	start := len(a.block)
+	a.block = append(a.block, v...)
+
+	return a.block[start:len(a.block):len(a.block)]
+}
@@ -0,0 +1,177 @@
+package datastore
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictStore(t *testing.T) {
+	t.Run("Bytes", TestDictStore_Bytes)
+	t.Run("Collision", TestDictStore_Collision)
+	t.Run("Comparable", TestDictStore_Comparable)
+	t.Run("Copy", TestDictStore_Copy)
+	t.Run("LargeValue", TestDictStore_LargeValue)
+	t.Run("MaxSize", TestDictStore_MaxSize)
+	t.Run("AddValues", TestDictStore_AddValues)
+}
+
+func addValues(t *testing.T, s *DictStore, values ...interface{}) {
+	t.Helper()
+
+	for _, v := range values {
+		require.NoError(t, s.AddValue(v, 0))
+	}
+}
+
+func readValues(t *testing.T, s *DictStore) []interface{} {
+	t.Helper()
+
+	var res []interface{}
+
+	for range s.Data {
+		v, err := s.GetNextValue()
+		require.NoError(t, err)
+
+		res = append(res, v)
+	}
+
+	return res
+}
+
+func TestDictStore_Bytes(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{}
+	s.Init()
+
+	addValues(t, s, []byte("a"), []byte("b"), []byte("a"), []byte{}, nil, []byte("b"), []byte{})
+
+	assert.Equal(t, []interface{}{[]byte("a"), []byte("b"), []byte{}}, s.Values)
+	assert.Equal(t, []int32{0, 1, 0, 2, 1, 2}, s.Data)
+	// The values are counted with their 4 bytes length prefix.
+	assert.Equal(t, int64(5+5+4), s.DictionarySize())
+	assert.Equal(t, []interface{}{[]byte("a"), []byte("b"), []byte("a"), []byte{}, []byte("b"), []byte{}}, readValues(t, s))
+}
+
+func TestDictStore_Collision(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{}
+	s.Init()
+
+	// All the values have the same hash.
+	s.hashFunc = func([]byte) uint64 { return 42 }
+
+	addValues(t, s, []byte("x"), []byte("y"), []byte("z"), []byte("y"), []byte("x"), [12]byte{1}, [12]byte{2}, [12]byte{1})
+
+	assert.Equal(t, []interface{}{[]byte("x"), []byte("y"), []byte("z"), [12]byte{1}, [12]byte{2}}, s.Values)
+	assert.Equal(t, []int32{0, 1, 2, 1, 0, 3, 4, 3}, s.Data)
+}
+
+func TestDictStore_Comparable(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{}
+	s.Init()
+
+	addValues(t, s, int32(1), int32(2), int32(1), int64(1), "a", "a", true)
+
+	assert.Equal(t, []interface{}{int32(1), int32(2), int64(1), "a", true}, s.Values)
+	assert.Equal(t, []int32{0, 1, 0, 2, 3, 3, 4}, s.Data)
+	assert.Equal(t, int64(4+4+8+5+1), s.DictionarySize())
+}
+
+func TestDictStore_Copy(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{}
+	s.Init()
+
+	buf := []byte("abc")
+	addValues(t, s, buf)
+
+	// The dictionary keeps its own copy of the values.
+	buf[0] = 'x'
+	addValues(t, s, []byte("abc"), buf)
+
+	assert.Equal(t, []interface{}{[]byte("abc"), []byte("xbc")}, s.Values)
+	assert.Equal(t, []int32{0, 0, 1}, s.Data)
+
+	// The values stored in the arena can't overwrite each other.
+	first := s.Values[0].([]byte)
+	_ = append(first, 'd')
+	assert.Equal(t, []byte("xbc"), s.Values[1])
+}
+
+func TestDictStore_LargeValue(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{}
+	s.Init()
+
+	large := make([]byte, arenaBlockSize)
+	large[0] = 1
+
+	addValues(t, s, []byte("a"), large, large, []byte("b"))
+
+	assert.Equal(t, []int32{0, 1, 1, 2}, s.Data)
+	assert.Equal(t, large, s.Values[1])
+	assert.Equal(t, int64(arenaBlockSize+3*4+2), s.DictionarySize())
+}
+
+func TestDictStore_MaxSize(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{MaxSize: 7 + 6}
+	s.Init()
+
+	addValues(t, s, []byte("abc"), []byte("de"), []byte("abc"))
+
+	err := s.AddValue([]byte("f"), 0)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, ErrDictionaryFull))
+
+	// The values already in the dictionary can still be added.
+	addValues(t, s, []byte("de"))
+
+	assert.Equal(t, []interface{}{[]byte("abc"), []byte("de")}, s.Values)
+	assert.Equal(t, []int32{0, 1, 0, 1}, s.Data)
+
+	n := &DictStore{MaxSize: 6}
+	n.Init()
+
+	addValues(t, n, int32(1))
+	assert.Error(t, n.AddValue(int32(2), 0))
+}
+
+func TestDictStore_AddValues(t *testing.T) {
+	t.Parallel()
+
+	s := &DictStore{MaxSize: 3 * 5}
+	s.Init()
+
+	require.NoError(t, s.AddValues([]interface{}{[]byte("a"), []byte("b"), nil, []byte("a")}))
+
+	// "c" fits, but not "c" and "d": none of the values is added.
+	err := s.AddValues([]interface{}{[]byte("a"), []byte("c"), []byte("c"), []byte("d")})
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, ErrDictionaryFull))
+
+	assert.Equal(t, []interface{}{[]byte("a"), []byte("b")}, s.Values)
+	assert.Equal(t, []int32{0, 1, 0}, s.Data)
+	assert.Equal(t, int64(10), s.DictionarySize())
+
+	// The duplicates of a batch are counted once.
+	require.NoError(t, s.AddValues([]interface{}{[]byte("c"), []byte("b"), []byte("c")}))
+	assert.Equal(t, []int32{0, 1, 0, 2, 1, 2}, s.Data)
+
+	n := &DictStore{MaxSize: 8}
+	n.Init()
+
+	require.NoError(t, n.AddValues([]interface{}{int64(1), int64(1)}))
+	assert.Error(t, n.AddValues([]interface{}{int64(1), int64(2)}))
+	assert.Equal(t, []int32{0, 0}, n.Data)
+}
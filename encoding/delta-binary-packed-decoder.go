@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 // Generic decoder /////////////////////////////////////////////////////////////
@@ -33,7 +34,7 @@ func (d *deltaBinaryPackDecoder) readBlockHeader() (err error) {
 
 	if d.blockSize <= 0 || d.blockSize%128 != 0 {
 		return errors.WithFields(
-			errors.WithStack(errInvalidBlockSize),
+			errs.Wrap(errs.ErrCorruptPage, errors.WithStack(errInvalidBlockSize)),
 			errors.Fields{
 				"block-size": d.blockSize,
 			})
@@ -45,7 +46,7 @@ func (d *deltaBinaryPackDecoder) readBlockHeader() (err error) {
 
 	if d.miniblockCount <= 0 || d.blockSize%d.miniblockCount != 0 {
 		return errors.WithFields(
-			errors.WithStack(errInvalidMiniblockCount),
+			errs.Wrap(errs.ErrCorruptPage, errors.WithStack(errInvalidMiniblockCount)),
 			errors.Fields{
 				"miniblock-count": d.miniblockCount,
 			})
@@ -79,7 +80,7 @@ func (d *deltaBinaryPackDecoder) readMiniBlockHeader() error {
 		const maxMiniblockBitWidth = 32
 		if d.miniBlockBitWidth[i] > maxMiniblockBitWidth {
 			return errors.WithFields(
-				errors.New("invalid miniblock bit-width"),
+				errs.New(errs.ErrCorruptPage, "invalid miniblock bit-width"),
 				errors.Fields{
 					"miniblock-index": i,
 					"bit-width":       d.miniBlockBitWidth[i],
@@ -151,7 +152,7 @@ func (d *deltaBinaryPackDecoder) readPadding(w int32) error {
 		//  current block
 		l := (d.miniBlockValueCount/8)*w - d.miniBlockPosition
 		if l < 0 {
-			return errors.New("invalid stream")
+			return errs.New(errs.ErrCorruptPage, "invalid stream")
 		}
 
 		remaining := make([]byte, l)
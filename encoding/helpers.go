@@ -6,6 +6,7 @@ import (
 	"math"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 type byteReader struct {
@@ -33,7 +34,7 @@ func readUVarInt32(r io.Reader) (int32, error) {
 	}
 
 	if i > math.MaxInt32 {
-		return 0, errors.New("int32 out of range")
+		return 0, errs.New(errs.ErrCorruptPage, "int32 out of range")
 	}
 
 	return int32(i), nil
@@ -51,7 +52,7 @@ func readVarInt32(r io.Reader) (int32, error) {
 	}
 
 	if i > math.MaxInt32 || i < math.MinInt32 {
-		return 0, errors.New("int32 out of range")
+		return 0, errs.New(errs.ErrCorruptPage, "int32 out of range")
 	}
 
 	return int32(i), nil
@@ -8,6 +8,7 @@ import (
 	"math/bits"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 type HybridDecoder struct {
@@ -120,14 +121,14 @@ func (d *HybridDecoder) readRunHeader() error {
 	if h&1 == 1 {
 		d.bpCount = uint32(h >> 1)
 		if d.bpCount == 0 {
-			return errors.New("rle: empty bit-packed run")
+			return errs.New(errs.ErrCorruptPage, "rle: empty bit-packed run")
 		}
 
 		d.bpRunPos = 0
 	} else {
 		d.rleCount = uint32(h >> 1)
 		if d.rleCount == 0 {
-			return errors.New("rle: empty RLE run")
+			return errs.New(errs.ErrCorruptPage, "rle: empty RLE run")
 		}
 		return d.readRLERunValue()
 	}
@@ -163,7 +164,7 @@ func (d *HybridDecoder) readRLERunValue() error {
 	d.rleValue = decodeRLEValue(v)
 
 	if bits.LeadingZeros32(uint32(d.rleValue)) < 32-d.bitWidth {
-		return errors.New("rle: RLE run value is too large")
+		return errs.New(errs.ErrCorruptPage, "rle: RLE run value is too large")
 	}
 
 	return nil
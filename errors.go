@@ -0,0 +1,21 @@
+package parquet
+
+import (
+	"github.com/hexbee-net/parquet/errs"
+)
+
+// The kinds of the errors returned by the reader, to be tested with errors.Is.
+// See the errs package for their description.
+const (
+	ErrNotParquet          = errs.ErrNotParquet
+	ErrCorruptFooter       = errs.ErrCorruptFooter
+	ErrCorruptPage         = errs.ErrCorruptPage
+	ErrUnsupportedEncoding = errs.ErrUnsupportedEncoding
+	ErrUnsupportedCodec    = errs.ErrUnsupportedCodec
+	ErrUnsupportedType     = errs.ErrUnsupportedType
+	ErrSchemaMismatch      = errs.ErrSchemaMismatch
+)
+
+// Error is the error carrying the location in the file (row group, column path,
+// offset and page ordinal) of the errors of the kinds above. Use errors.As to retrieve it.
+type Error = errs.Error
@@ -0,0 +1,202 @@
+// Package errs defines the kinds of the errors returned when reading parquet files,
+// and the Error type carrying the location in the file where they happened.
+//
+// The kinds are sentinel values to be tested with errors.Is from the standard library:
+//
+//	if errors.Is(err, errs.ErrCorruptPage) {
+//		var e *errs.Error
+//		errors.As(err, &e)
+//		log.Printf("corrupt page %d of column %v in row group %d", e.Page, e.Column, e.RowGroup)
+//	}
+package errs
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/internal/columnpath"
+)
+
+const (
+	// ErrNotParquet is returned when the magic bytes of the file are missing.
+	ErrNotParquet = errors.Error("not a parquet file")
+	// ErrCorruptFooter is returned when the file metadata can't be read or is inconsistent.
+	ErrCorruptFooter = errors.Error("corrupt footer")
+	// ErrCorruptPage is returned when a page header or the page data can't be decoded.
+	ErrCorruptPage = errors.Error("corrupt page")
+	// ErrUnsupportedEncoding is returned when a page uses an encoding that can't be decoded.
+	ErrUnsupportedEncoding = errors.Error("unsupported encoding")
+	// ErrUnsupportedCodec is returned when a column chunk uses an unavailable compression codec.
+	ErrUnsupportedCodec = errors.Error("unsupported compression codec")
+	// ErrUnsupportedType is returned when the values of a column have a physical type that can't be decoded.
+	ErrUnsupportedType = errors.Error("unsupported type")
+	// ErrSchemaMismatch is returned when the column chunk metadata doesn't match the schema,
+	// or when a value doesn't match the definition of its column.
+	ErrSchemaMismatch = errors.Error("schema mismatch")
+)
+
+// Unknown is the value of the location fields of an Error that are not known.
+const Unknown = -1
+
+// Error is an error of a given kind, with its location in the file.
+// The location fields are set while the error goes up the call stack,
+// they are Unknown (or nil for Column) when they don't apply.
+type Error struct {
+	// Kind is one of the Err* sentinels.
+	Kind error
+	// RowGroup is the index of the row group.
+	RowGroup int
+	// Column is the path of the column.
+	Column []string
+	// Offset is the offset of the page, or of the column chunk, in the file.
+	Offset int64
+	// Page is the ordinal of the page in the column chunk, the dictionary page included.
+	Page int
+	// Err is the underlying error.
+	Err error
+}
+
+// New returns an Error of the given kind with the given message.
+func New(kind error, message string) error {
+	return Wrap(kind, errors.New(message))
+}
+
+// Newf returns an Error of the given kind with a formatted message.
+func Newf(kind error, format string, args ...interface{}) error {
+	return Wrap(kind, errors.Errorf(format, args...))
+}
+
+// Wrap returns err as an Error of the given kind.
+// If err already is an Error, its kind is more specific and it is returned unchanged.
+// If err is nil, Wrap returns nil.
+func Wrap(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if stderrors.As(err, &e) {
+		return err
+	}
+
+	return &Error{
+		Kind:     kind,
+		RowGroup: Unknown,
+		Offset:   Unknown,
+		Page:     Unknown,
+		Err:      err,
+	}
+}
+
+// WithRowGroup sets the row group of the Error found in the chain of err, if it isn't known yet.
+// err is returned so calls can be chained.
+func WithRowGroup(err error, rowGroup int) error {
+	if e := find(err); e != nil && e.RowGroup == Unknown {
+		e.RowGroup = rowGroup
+	}
+
+	return err
+}
+
+// WithColumn sets the column path of the Error found in the chain of err, if it isn't known yet.
+func WithColumn(err error, path []string) error {
+	if e := find(err); e != nil && e.Column == nil {
+		e.Column = path
+	}
+
+	return err
+}
+
+// WithOffset sets the file offset of the Error found in the chain of err, if it isn't known yet.
+func WithOffset(err error, offset int64) error {
+	if e := find(err); e != nil && e.Offset == Unknown {
+		e.Offset = offset
+	}
+
+	return err
+}
+
+// WithPage sets the page ordinal of the Error found in the chain of err, if it isn't known yet.
+func WithPage(err error, page int) error {
+	if e := find(err); e != nil && e.Page == Unknown {
+		e.Page = page
+	}
+
+	return err
+}
+
+func find(err error) *Error {
+	var e *Error
+	if err == nil || !stderrors.As(err, &e) {
+		return nil
+	}
+
+	return e
+}
+
+func (e *Error) Error() string {
+	var loc []string
+
+	if e.RowGroup != Unknown {
+		loc = append(loc, fmt.Sprintf("row group %d", e.RowGroup))
+	}
+
+	if e.Column != nil {
+		loc = append(loc, fmt.Sprintf("column %s", columnpath.Format(e.Column)))
+	}
+
+	if e.Page != Unknown {
+		loc = append(loc, fmt.Sprintf("page %d", e.Page))
+	}
+
+	if e.Offset != Unknown {
+		loc = append(loc, fmt.Sprintf("offset %d", e.Offset))
+	}
+
+	msg := e.Kind.Error()
+	if len(loc) > 0 {
+		msg += " (" + strings.Join(loc, ", ") + ")"
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+// Is reports whether target is the kind of the error.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the underlying error, for errors.Cause and errors.GetFields.
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// Fields returns the known location of the error, for errors.GetFields.
+func (e *Error) Fields() errors.Fields {
+	fields := errors.Fields{}
+
+	if e.RowGroup != Unknown {
+		fields["row-group"] = e.RowGroup
+	}
+
+	if e.Column != nil {
+		fields["column"] = columnpath.Format(e.Column)
+	}
+
+	if e.Page != Unknown {
+		fields["page"] = e.Page
+	}
+
+	if e.Offset != Unknown {
+		fields["offset"] = e.Offset
+	}
+
+	return fields
+}
@@ -0,0 +1,112 @@
+package errs
+
+import (
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/hexbee-net/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	t.Run("IsThroughWrappers", TestError_IsThroughWrappers)
+	t.Run("Location", TestError_Location)
+	t.Run("LocationIsSetOnce", TestError_LocationIsSetOnce)
+	t.Run("WrapKeepsInnerKind", TestError_WrapKeepsInnerKind)
+	t.Run("Message", TestError_Message)
+	t.Run("Fields", TestError_Fields)
+	t.Run("NotAnError", TestError_NotAnError)
+}
+
+func TestError_IsThroughWrappers(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(
+		errors.WithFields(New(ErrCorruptPage, "invalid page data size"), errors.Fields{"size": -1}),
+		"failed to read data chunk")
+
+	assert.True(t, stderrors.Is(err, ErrCorruptPage))
+	assert.False(t, stderrors.Is(err, ErrCorruptFooter))
+
+	var e *Error
+
+	require.True(t, stderrors.As(err, &e))
+	assert.Equal(t, ErrCorruptPage, e.Kind)
+}
+
+func TestError_Location(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(New(ErrUnsupportedEncoding, "encoding not supported for int32"), "failed to read page")
+	err = WithOffset(WithPage(err, 2), 1234)
+	err = WithColumn(err, []string{"a", "b"})
+	err = WithRowGroup(err, 3)
+
+	var e *Error
+
+	require.True(t, stderrors.As(err, &e))
+	assert.Equal(t, 3, e.RowGroup)
+	assert.Equal(t, []string{"a", "b"}, e.Column)
+	assert.Equal(t, int64(1234), e.Offset)
+	assert.Equal(t, 2, e.Page)
+}
+
+func TestError_LocationIsSetOnce(t *testing.T) {
+	t.Parallel()
+
+	err := WithOffset(New(ErrCorruptPage, "invalid stream"), 10)
+	err = WithOffset(err, 20)
+
+	var e *Error
+
+	require.True(t, stderrors.As(err, &e))
+	assert.Equal(t, int64(10), e.Offset)
+}
+
+func TestError_WrapKeepsInnerKind(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(ErrCorruptPage, errors.Wrap(New(ErrUnsupportedCodec, "compression method not supported"), "failed to decompress block"))
+
+	assert.True(t, stderrors.Is(err, ErrUnsupportedCodec))
+	assert.False(t, stderrors.Is(err, ErrCorruptPage))
+	assert.Nil(t, Wrap(ErrCorruptPage, nil))
+}
+
+func TestError_Message(t *testing.T) {
+	t.Parallel()
+
+	err := New(ErrCorruptPage, "invalid index")
+	assert.Equal(t, "corrupt page: invalid index", err.Error())
+
+	err = WithRowGroup(WithColumn(WithPage(WithOffset(err, 4), 1), []string{"a", "b"}), 0)
+	assert.Equal(t, "corrupt page (row group 0, column a.b, page 1, offset 4): invalid index", err.Error())
+
+	// The dots of the names are escaped, like in schema.FormatPath.
+	err = WithColumn(New(ErrCorruptPage, "invalid index"), []string{"a.b"})
+	assert.Equal(t, `corrupt page (column a\.b): invalid index`, err.Error())
+}
+
+func TestError_Fields(t *testing.T) {
+	t.Parallel()
+
+	err := errors.WithFields(New(ErrCorruptPage, "invalid index"), errors.Fields{"index": 12})
+	err = WithColumn(WithPage(err, 1), []string{"a.b", "c"})
+
+	assert.Equal(t, errors.Fields{
+		"index":  12,
+		"page":   1,
+		"column": `a\.b.c`,
+	}, errors.GetFields(err))
+}
+
+func TestError_NotAnError(t *testing.T) {
+	t.Parallel()
+
+	err := WithRowGroup(WithColumn(io.ErrUnexpectedEOF, []string{"a"}), 1)
+
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, io.ErrUnexpectedEOF, errors.Cause(Wrap(ErrCorruptPage, io.ErrUnexpectedEOF)))
+}
@@ -2,12 +2,15 @@ package parquet
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"io"
-	"strings"
+	"time"
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/compression"
+	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/layout"
 	"github.com/hexbee-net/parquet/parquet"
 	"github.com/hexbee-net/parquet/schema"
@@ -30,10 +33,15 @@ type FileReader struct {
 	reader source.Reader
 
 	chunkReader *layout.ChunkReader
+	planner     *layout.PlannerOptions
+	readerAt    source.ReaderAt
+	observer    *chunkObserver
+	lenient     *LenientOptions
 
 	rowGroupPosition int
 	currentRecord    int64
 	skipRowGroup     bool
+	dropped          []rowRange
 }
 
 // NewFileReader creates a new FileReader.
@@ -46,12 +54,29 @@ func NewFileReader(r source.Reader, columns ...string) (*FileReader, error) {
 		return nil, errors.Wrap(err, "failed to read file meta data")
 	}
 
-	s, err := readFileSchema(meta)
+	return newFileReader(r, meta, false, columns...)
+}
+
+// NewStrictFileReader is NewFileReader in strict mode: the file schema must follow
+// the rules of the parquet format for the logical types (see schema.Validate).
+func NewStrictFileReader(r source.Reader, columns ...string) (*FileReader, error) {
+	meta, err := readFileMetaData(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file meta data")
+	}
+
+	return newFileReader(r, meta, true, columns...)
+}
+
+func newFileReader(r source.Reader, meta *parquet.FileMetaData, strict bool, columns ...string) (*FileReader, error) {
+	s, err := readFileSchema(meta, strict)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating schema failed")
 	}
 
-	s.SetSelectedColumns(columns...)
+	if err := s.SetSelectedColumns(columns...); err != nil {
+		return nil, err
+	}
 
 	// Reset the reader to the beginning of the file
 	if _, err := r.Seek(int64(magicLen), io.SeekStart); err != nil {
@@ -88,7 +113,7 @@ func (f *FileReader) NumRows() int64 {
 
 // RowGroupNumRows returns the number of rows in the current RowGroup.
 func (f *FileReader) RowGroupNumRows() (int64, error) {
-	if err := f.advanceIfNeeded(); err != nil {
+	if err := f.advanceIfNeeded(context.Background()); err != nil {
 		return 0, err
 	}
 
@@ -97,13 +122,25 @@ func (f *FileReader) RowGroupNumRows() (int64, error) {
 
 // NextRow reads the next row from the parquet file. If required, it will load the next row group.
 func (f *FileReader) NextRow() (map[string]interface{}, error) {
-	if err := f.advanceIfNeeded(); err != nil {
-		return nil, err
-	}
+	return f.NextRowContext(context.Background())
+}
+
+// NextRowContext is the same as NextRow, but loading the next row group,
+// if required, can be cancelled through the provided context.
+func (f *FileReader) NextRowContext(ctx context.Context) (map[string]interface{}, error) {
+	for {
+		if err := f.advanceIfNeeded(ctx); err != nil {
+			return nil, err
+		}
 
-	f.currentRecord++
+		row := f.currentRecord
+		f.currentRecord++
 
-	return f.Reader.GetData()
+		data, err := f.Reader.GetData()
+		if err != nil || !f.isDropped(row) {
+			return data, err
+		}
+	}
 }
 
 // SkipRowGroup skips the currently loaded row group and advances to the next row group.
@@ -111,9 +148,45 @@ func (f *FileReader) SkipRowGroup() {
 	f.skipRowGroup = true
 }
 
+// SetConcurrency limits the number of column chunks loaded at the same time when
+// the source supports positioned reads (see source.ReaderAt).
+// A value lower than 1 means that all the selected chunks of a row group are loaded at the same time.
+func (f *FileReader) SetConcurrency(n int) {
+	f.chunkReader.SetConcurrency(n)
+}
+
+// SetPlannerOptions enables the I/O planner when opts is not nil.
+// Instead of reading each selected column chunk on its own, the planner computes the
+// byte ranges of all the selected chunks of a row group, merges the close ones and
+// fetches them up front, concurrently, before decoding the chunks from memory.
+// This greatly reduces the number of requests issued to remote sources.
+func (f *FileReader) SetPlannerOptions(opts *layout.PlannerOptions) {
+	f.planner = opts
+}
+
+// SetObserver sets the observer notified of the row groups, column chunks and pages
+// loaded by the reader, nil disables the notifications.
+func (f *FileReader) SetObserver(o ReaderObserver) {
+	if o == nil {
+		f.observer = nil
+		f.chunkReader.SetObserver(nil)
+
+		return
+	}
+
+	f.observer = &chunkObserver{observer: o}
+	f.chunkReader.SetObserver(f.observer)
+}
+
 // PreLoad is used to load the row group if required. It does nothing if the row group is already loaded.
 func (f *FileReader) PreLoad() error {
-	return f.advanceIfNeeded()
+	return f.PreLoadContext(context.Background())
+}
+
+// PreLoadContext is the same as PreLoad, but loading the row group can be
+// cancelled through the provided context.
+func (f *FileReader) PreLoadContext(ctx context.Context) error {
+	return f.advanceIfNeeded(ctx)
 }
 
 // MetaData returns a map of metadata key-value pairs stored in the parquet file.
@@ -123,10 +196,15 @@ func (f *FileReader) MetaData() map[string]string {
 
 // ColumnMetaData returns a map of metadata key-value pairs for the provided column
 // in the current row group.
-// The column name has to be provided in its dotted notation.
+// The column name has to be provided in its dotted notation (see schema.ParsePath).
 func (f *FileReader) ColumnMetaData(colName string) (map[string]string, error) {
+	return f.ColumnMetaDataByPath(schema.ParsePath(colName))
+}
+
+// ColumnMetaDataByPath is the same as ColumnMetaData, with the column provided by its path.
+func (f *FileReader) ColumnMetaDataByPath(path []string) (map[string]string, error) {
 	for _, col := range f.CurrentRowGroup().Columns {
-		if colName == strings.Join(col.MetaData.PathInSchema, ".") {
+		if schema.PathEqual(path, col.MetaData.PathInSchema) {
 			return metaDataToMap(col.MetaData.KeyValueMetadata), nil
 		}
 	}
@@ -134,15 +212,22 @@ func (f *FileReader) ColumnMetaData(colName string) (map[string]string, error) {
 	return nil, errors.WithFields(
 		errors.New("column not found"),
 		errors.Fields{
-			"name": colName,
+			"name": schema.FormatPath(path),
 		})
 }
 
-func (f *FileReader) advanceIfNeeded() error {
+func (f *FileReader) advanceIfNeeded(ctx context.Context) error {
 	if f.rowGroupPosition == 0 || f.currentRecord >= f.Reader.RowGroupNumRecords() || f.skipRowGroup {
-		if err := f.readRowGroup(); err != nil {
-			f.skipRowGroup = true
-			return err
+		for {
+			err := f.readRowGroup(ctx)
+			if err == nil {
+				break
+			}
+
+			if !f.dropRowGroup(ctx, err) {
+				f.skipRowGroup = true
+				return err
+			}
 		}
 
 		f.currentRecord = 0
@@ -153,7 +238,7 @@ func (f *FileReader) advanceIfNeeded() error {
 }
 
 // readRowGroup read the next row group into memory.
-func (f *FileReader) readRowGroup() error {
+func (f *FileReader) readRowGroup(ctx context.Context) (err error) {
 	if f.rowGroupPosition >= len(f.meta.RowGroups) {
 		return io.EOF
 	}
@@ -161,14 +246,56 @@ func (f *FileReader) readRowGroup() error {
 	rowGroups := f.meta.RowGroups[f.rowGroupPosition]
 	f.rowGroupPosition++
 
+	if f.observer != nil {
+		e := RowGroupEvent{
+			Index:         f.rowGroupPosition - 1,
+			NumRows:       rowGroups.NumRows,
+			TotalByteSize: rowGroups.TotalByteSize,
+		}
+
+		f.observer.rowGroup = e.Index
+		f.observer.observer.RowGroupStart(e)
+
+		start := time.Now()
+
+		defer func() {
+			e.Duration = time.Since(start)
+			e.Err = err
+
+			f.observer.observer.RowGroupEnd(e)
+		}()
+	}
+
+	index := f.rowGroupPosition - 1
+
+	defer func() {
+		err = errs.WithRowGroup(err, index)
+	}()
+
 	f.Reader.ResetData()
 	f.Reader.SetNumRecords(rowGroups.NumRows)
 
+	f.dropped = f.dropped[:0]
+
+	var (
+		columns []*schema.Column
+		chunks  []*parquet.ColumnChunk
+	)
+
 	for _, c := range f.Reader.Columns() {
+		if c.Index() >= len(rowGroups.Columns) {
+			return errors.WithFields(
+				errs.New(errs.ErrSchemaMismatch, "missing column chunk in row group"),
+				errors.Fields{
+					"column-index": c.Index(),
+					"chunks":       len(rowGroups.Columns),
+				})
+		}
+
 		chunk := rowGroups.Columns[c.Index()]
 
-		if !f.Reader.IsSelected(c.FlatName()) {
-			if err := layout.SkipChunk(f.reader, c, chunk); err != nil {
+		if !f.Reader.IsPathSelected(c.Path()) {
+			if err := layout.SkipChunk(f.reader, c, chunk); err != nil && !f.canSkip(SkipRowGroup, err) {
 				return err
 			}
 
@@ -177,46 +304,170 @@ func (f *FileReader) readRowGroup() error {
 			continue
 		}
 
-		pages, err := f.chunkReader.ReadChunk(f.reader, c, chunk)
-		if err != nil {
-			return errors.Wrap(err, "failed to read data chunk")
+		columns = append(columns, c)
+		chunks = append(chunks, chunk)
+
+		f.hintDictionaryPage(chunk)
+	}
+
+	pages, err := f.readChunks(ctx, columns, chunks)
+	if err != nil && ctx.Err() == nil && f.canSkip(SkipChunk, err) {
+		pages, err = f.readChunksLenient(ctx, columns, chunks)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// The row group was not consumed, it will be loaded again on the next call.
+			f.rowGroupPosition--
 		}
 
-		if err := readPageData(c, pages); err != nil {
-			return errors.Wrap(err, "failed to read page data")
+		return err
+	}
+
+	for i, c := range columns {
+		if err := f.readPageData(c, pages[i]); err != nil {
+			err = errors.Wrap(errs.WithColumn(err, c.Path()), "failed to read page data")
+			if err := f.skipChunk(c, err); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-func readFileMetaData(r io.ReadSeeker) (*parquet.FileMetaData, error) {
+// hintDictionaryPage tells the source where the dictionary page of the chunk is, if it has one.
+func (f *FileReader) hintDictionaryPage(chunk *parquet.ColumnChunk) {
+	h, ok := f.reader.(source.RangeHinter)
+	if !ok || chunk.MetaData == nil || chunk.MetaData.DictionaryPageOffset == nil {
+		return
+	}
+
+	offset := *chunk.MetaData.DictionaryPageOffset
+	if length := chunk.MetaData.DataPageOffset - offset; length > 0 {
+		h.HintRange(source.RangeDictionaryPage, offset, length)
+	}
+}
+
+// readChunks reads the pages of the provided column chunks. If the source supports
+// positioned reads, the chunks are read concurrently.
+func (f *FileReader) readChunks(ctx context.Context, columns []*schema.Column, chunks []*parquet.ColumnChunk) ([][]layout.PageReader, error) {
+	if f.planner != nil {
+		return f.readPlannedChunks(ctx, columns, chunks)
+	}
+
+	if ra, ok := f.reader.(source.ReaderAt); ok {
+		return f.chunkReader.ReadChunksAt(ctx, ra, columns, chunks)
+	}
+
+	pages := make([][]layout.PageReader, len(columns))
+
+	for i := range columns {
+		p, err := f.chunkReader.ReadChunkContext(ctx, f.reader, columns[i], chunks[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read data chunk")
+		}
+
+		pages[i] = p
+	}
+
+	return pages, nil
+}
+
+// readChunksLenient reads the provided column chunks one by one, the chunks that
+// can't be read are skipped.
+func (f *FileReader) readChunksLenient(ctx context.Context, columns []*schema.Column, chunks []*parquet.ColumnChunk) ([][]layout.PageReader, error) {
+	pages := make([][]layout.PageReader, len(columns))
+
+	for i := range columns {
+		p, err := f.readChunks(ctx, columns[i:i+1], chunks[i:i+1])
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+
+			if err := f.skipChunk(columns[i], err); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		pages[i] = p[0]
+	}
+
+	return pages, nil
+}
+
+// readPlannedChunks prefetches the byte ranges of the provided column chunks
+// with as few requests as possible and decodes the chunks from memory.
+func (f *FileReader) readPlannedChunks(ctx context.Context, columns []*schema.Column, chunks []*parquet.ColumnChunk) ([][]layout.PageReader, error) {
+	if f.readerAt == nil {
+		ra, err := source.NewReaderAt(f.reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create positioned reader")
+		}
+
+		f.readerAt = ra
+	}
+
+	ranges := layout.PlanChunkRanges(chunks, *f.planner)
+
+	buf, err := layout.FetchRanges(ctx, f.readerAt, ranges, f.planner.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.chunkReader.ReadChunksAt(ctx, buf, columns, chunks)
+}
+
+func readMagicHeader(r io.ReadSeeker) error {
 	buf := make([]byte, magicLen)
 
-	// read and validate magic header
 	if _, err := r.Seek(0, io.SeekStart); err != nil {
-		return nil, errors.Wrap(err, "failed to seek to file magic header")
+		return errors.Wrap(err, "failed to seek to file magic header")
 	}
 
 	if _, err := io.ReadFull(r, buf); err != nil {
-		return nil, errors.Wrap(err, "failed to read file magic header failed")
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return errs.Wrap(errs.ErrNotParquet, errors.Wrap(err, "file too short"))
+		}
+
+		return errors.Wrap(err, "failed to read file magic header failed")
 	}
 
 	if !bytes.Equal(buf, []byte(magic)) {
-		return nil, errors.New("invalid parquet file header")
+		return errs.New(errs.ErrNotParquet, "invalid parquet file header")
+	}
+
+	return nil
+}
+
+func readFileMetaData(r io.ReadSeeker) (*parquet.FileMetaData, error) {
+	if err := readMagicHeader(r); err != nil {
+		return nil, err
 	}
 
+	buf := make([]byte, magicLen)
+
 	// read and validate footer
-	if _, err := r.Seek(int64(-magicLen), io.SeekEnd); err != nil {
+	size, err := r.Seek(int64(-magicLen), io.SeekEnd)
+	if err != nil {
 		return nil, errors.Wrap(err, "failed to seek to file magic footer")
 	}
 
+	size += int64(magicLen)
+
+	if size < int64(magicLen)+footerLen {
+		return nil, errs.WithOffset(errs.New(errs.ErrCorruptFooter, "file too short"), 0)
+	}
+
 	if _, err := io.ReadFull(r, buf); err != nil {
 		return nil, errors.Wrap(err, "failed to read file magic footer failed")
 	}
 
 	if !bytes.Equal(buf, []byte(magic)) {
-		return nil, errors.Errorf("invalid parquet file footer")
+		return nil, errs.WithOffset(errs.New(errs.ErrCorruptFooter, "invalid parquet file footer"), size-int64(magicLen))
 	}
 
 	// read footer length
@@ -230,9 +481,9 @@ func readFileMetaData(r io.ReadSeeker) (*parquet.FileMetaData, error) {
 		return nil, errors.Wrap(err, "failed to read footer length")
 	}
 
-	if fl <= 0 {
+	if fl <= 0 || int64(fl) > size-int64(magicLen)-footerLen {
 		return nil, errors.WithFields(
-			errors.New("invalid footer length"),
+			errs.WithOffset(errs.New(errs.ErrCorruptFooter, "invalid footer length"), size-footerLen),
 			errors.Fields{
 				"length": fl,
 			})
@@ -241,48 +492,68 @@ func readFileMetaData(r io.ReadSeeker) (*parquet.FileMetaData, error) {
 	// read file metadata
 	meta := &parquet.FileMetaData{}
 
-	if _, err := r.Seek(-footerLen-int64(fl), io.SeekEnd); err != nil {
+	metaOffset, err := r.Seek(-footerLen-int64(fl), io.SeekEnd)
+	if err != nil {
 		return nil, errors.Wrap(err, "failed to seek to file meta data")
 	}
 
+	if h, ok := r.(source.RangeHinter); ok {
+		h.HintRange(source.RangeFooter, metaOffset, int64(fl)+footerLen)
+	}
+
 	if err := readThrift(meta, io.LimitReader(r, int64(fl))); err != nil {
-		return nil, errors.Wrap(err, "failed to read file meta data")
+		return nil, errs.WithOffset(errs.Wrap(errs.ErrCorruptFooter, errors.Wrap(err, "failed to read file meta data")), metaOffset)
 	}
 
 	return meta, nil
 }
 
-func readFileSchema(meta *parquet.FileMetaData) (schema.Reader, error) {
+func readFileSchema(meta *parquet.FileMetaData, strict bool) (schema.Reader, error) {
 	if len(meta.Schema) < 1 {
-		return nil, errors.New("no schema element found")
+		return nil, errs.New(errs.ErrCorruptFooter, "no schema element found")
 	}
 
 	s, err := schema.LoadSchema(meta.Schema)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read file schema from meta data")
+		return nil, errs.Wrap(errs.ErrCorruptFooter, errors.Wrap(err, "failed to read file schema from meta data"))
+	}
+
+	if strict {
+		if err := schema.Validate(s.GetSchemaDefinition()); err != nil {
+			return nil, errors.Wrap(err, "invalid file schema")
+		}
 	}
 
 	return s, nil
 }
 
-func readPageData(col *schema.Column, pages []layout.PageReader) error {
+// readPageData loads the values of the pages in the column store. In lenient mode,
+// the skipped pages and the pages of flat columns that fail to be read are replaced by nulls.
+func (f *FileReader) readPageData(col *schema.Column, pages []layout.PageReader) error {
 	s := col.ColumnStore()
 
 	for i := range pages {
-		data := make([]interface{}, pages[i].NumValues())
+		if p, ok := pages[i].(*layout.SkippedPage); ok {
+			f.skipPage(col, p, p.Err)
+			continue
+		}
 
-		n, dl, rl, err := pages[i].ReadValues(data)
-		if err != nil {
-			return err
+		if pages[i].NumValues() == 0 {
+			continue
 		}
 
-		if int32(n) != pages[i].NumValues() {
-			return errors.WithFields(
-				errors.New("unexpected number of values"),
-				errors.Fields{
-					"expected": pages[i].NumValues(),
-					"actual":   n,
-				})
+		data, dl, rl, err := readPageValues(col, pages[i])
+		if err != nil {
+			ordinal, offset := pages[i].Location()
+			err = errs.WithOffset(errs.WithPage(err, ordinal), offset)
+
+			if col.MaxRepetitionLevel() > 0 || !f.canSkip(SkipPage, err) {
+				return err
+			}
+
+			f.skipPage(col, pages[i], errs.WithColumn(err, col.Path()))
+
+			continue
 		}
 
 		// using append to make sure we handle the multiple data page correctly
@@ -301,6 +572,40 @@ func readPageData(col *schema.Column, pages []layout.PageReader) error {
 	return nil
 }
 
+// readPageValues reads all the values of a page, the nulls excluded.
+func readPageValues(col *schema.Column, p layout.PageReader) ([]interface{}, *encoding.PackedArray, *encoding.PackedArray, error) {
+	data := make([]interface{}, p.NumValues())
+
+	n, dl, rl, err := p.ReadValues(data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if n == 0 {
+		return nil, dl, rl, nil
+	}
+
+	if int32(n) != p.NumValues() {
+		return nil, nil, nil, errors.WithFields(
+			errs.New(errs.ErrCorruptPage, "unexpected number of values"),
+			errors.Fields{
+				"expected": p.NumValues(),
+				"actual":   n,
+			})
+	}
+
+	// Only the values that are not null are decoded, at the start of data.
+	notNull := 0
+
+	for i := 0; i < dl.Count(); i++ {
+		if d, _ := dl.At(i); d == int32(col.MaxDefinitionLevel()) {
+			notNull++
+		}
+	}
+
+	return data[:notNull], dl, rl, nil
+}
+
 func metaDataToMap(kvMetaData []*parquet.KeyValue) map[string]string {
 	data := make(map[string]string)
 
@@ -0,0 +1,312 @@
+package parquet
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/source"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReader(t *testing.T) {
+	t.Run("OptionalNulls", TestFileReader_OptionalNulls)
+	t.Run("ContextCancelled", TestFileReader_ContextCancelled)
+	t.Run("ContextCancelledInRowGroup", TestFileReader_ContextCancelledInRowGroup)
+	t.Run("ChunkRequests", TestFileReader_ChunkRequests)
+	t.Run("ReaderAt", TestFileReader_ReaderAt)
+	t.Run("ReaderAtError", TestFileReader_ReaderAtError)
+	t.Run("ReaderAtCancelled", TestFileReader_ReaderAtCancelled)
+}
+
+func TestFileReader_OptionalNulls(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{
+		{name: "id", factor: 1},
+		{name: "a", optional: true, factor: 2},
+	}, 2, 10, 3)
+
+	r, err := NewFileReader(memory.NewReader(file.data))
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		row, err := r.NextRow()
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(i), row["id"])
+
+		// The values following a null in a page are not shifted.
+		if i%3 == 0 {
+			assert.NotContains(t, row, "a", "row %d", i)
+		} else {
+			assert.Equal(t, int64(i*2), row["a"], "row %d", i)
+		}
+	}
+
+	_, err = r.NextRow()
+	assert.Equal(t, io.EOF, err)
+}
+
+// cancelReader is a sequential source calling cancel the first time it reads past offset.
+type cancelReader struct {
+	source.Reader
+
+	offset int64
+	cancel context.CancelFunc
+	pos    int64
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.pos += int64(n)
+
+	if r.cancel != nil && r.pos > r.offset {
+		r.cancel()
+		r.cancel = nil
+	}
+
+	return n, err
+}
+
+func (r *cancelReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.Reader.Seek(offset, whence)
+	if err == nil {
+		r.pos = pos
+	}
+
+	return pos, err
+}
+
+func TestFileReader_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{{name: "id", factor: 1}}, 2, 10, 2)
+
+	r, err := NewFileReader(memory.NewReader(file.data))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = r.NextRowContext(ctx)
+	assert.True(t, stderrors.Is(err, context.Canceled))
+	assert.Equal(t, 0, r.rowGroupPosition)
+
+	assert.True(t, stderrors.Is(r.PreLoadContext(ctx), context.Canceled))
+	assert.Equal(t, 0, r.rowGroupPosition)
+
+	// The reader resumes with a live context.
+	require.NoError(t, r.PreLoadContext(context.Background()))
+	assert.Equal(t, 1, r.rowGroupPosition)
+
+	row, err := r.NextRowContext(ctx)
+	require.NoError(t, err, "the row group is already loaded")
+	assert.Equal(t, int64(0), row["id"])
+}
+
+func TestFileReader_ContextCancelledInRowGroup(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{
+		{name: "id", factor: 1},
+		{name: "a", factor: 2},
+	}, 2, 10, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancelled while reading the first page of the second chunk of the second row group.
+	src := &cancelReader{
+		Reader: memory.NewReader(file.data),
+		offset: int64(file.pages[1][1][0][0]),
+	}
+
+	r, err := NewFileReader(src)
+	require.NoError(t, err)
+
+	src.cancel = cancel
+
+	for i := 0; i < 10; i++ {
+		row, err := r.NextRowContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(i), row["id"])
+	}
+
+	_, err = r.NextRowContext(ctx)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, context.Canceled))
+
+	// The second row group was not consumed.
+	assert.Equal(t, 1, r.rowGroupPosition)
+
+	for i := 10; i < 20; i++ {
+		row, err := r.NextRowContext(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(i), row["id"])
+		assert.Equal(t, int64(i*2), row["a"])
+	}
+
+	_, err = r.NextRowContext(context.Background())
+	assert.Equal(t, io.EOF, err)
+}
+
+// countingReaderAt is a positioned source counting the requests it serves.
+type countingReaderAt struct {
+	*memory.Reader
+
+	mu       sync.Mutex
+	requests int
+}
+
+func (r *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	r.requests++
+	r.mu.Unlock()
+
+	return r.Reader.ReadAt(p, off)
+}
+
+func TestFileReader_ChunkRequests(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{
+		{name: "id", factor: 1},
+		{name: "a", optional: true, factor: 2},
+	}, 2, 100, 10)
+
+	src := &countingReaderAt{Reader: memory.NewReader(file.data)}
+
+	r, err := NewFileReader(src)
+	require.NoError(t, err)
+
+	for i := 0; i < 200; i++ {
+		row, err := r.NextRow()
+		require.NoError(t, err)
+		assert.Equal(t, int64(i), row["id"])
+	}
+
+	// A single request for each column chunk, instead of one for each page header and page.
+	assert.Equal(t, 4, src.requests)
+}
+
+// sequentialReader hides the ReadAt method of a source.
+type sequentialReader struct {
+	source.Reader
+}
+
+func TestFileReader_ReaderAt(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{
+		{name: "id", factor: 1},
+		{name: "a", optional: true, factor: 2},
+		{name: "b", optional: true, factor: 3},
+	}, 3, 50, 4)
+
+	seq, err := NewFileReader(&sequentialReader{Reader: memory.NewReader(file.data)})
+	require.NoError(t, err)
+
+	expected, err := readRows(seq)
+	require.NoError(t, err)
+	require.Len(t, expected, 150)
+
+	for _, concurrency := range []int{0, 1, 2} {
+		r, err := NewFileReader(memory.NewReader(file.data))
+		require.NoError(t, err)
+
+		r.SetConcurrency(concurrency)
+
+		rows, err := readRows(r)
+		require.NoError(t, err)
+		assert.Equal(t, expected, rows, "concurrency %d", concurrency)
+	}
+}
+
+// failingReaderAt fails the positioned reads from offset, cancelling the context
+// of the read when cancel is set.
+type failingReaderAt struct {
+	*memory.Reader
+
+	offset int64
+	cancel context.CancelFunc
+}
+
+func (r *failingReaderAt) ReadAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	if off+int64(len(p)) <= r.offset {
+		return r.ReadAt(p, off)
+	}
+
+	if r.cancel != nil {
+		r.cancel()
+		return 0, ctx.Err()
+	}
+
+	return 0, stderrors.New("read failure")
+}
+
+func TestFileReader_ReaderAtError(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{
+		{name: "id", factor: 1},
+		{name: "a", optional: true, factor: 2},
+	}, 2, 10, 2)
+
+	r, err := NewFileReader(&failingReaderAt{
+		Reader: memory.NewReader(file.data),
+		offset: int64(file.pages[1][1][0][0]),
+	})
+	require.NoError(t, err)
+
+	rows, err := readRows(r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read failure")
+	assert.Len(t, rows, 10)
+
+	// The failure of the source isn't reported as a corrupt page.
+	assert.False(t, stderrors.Is(err, errs.ErrCorruptPage))
+}
+
+func TestFileReader_ReaderAtCancelled(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, []testColumn{
+		{name: "id", factor: 1},
+		{name: "a", optional: true, factor: 2},
+	}, 2, 10, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := &failingReaderAt{
+		Reader: memory.NewReader(file.data),
+		offset: int64(file.pages[1][1][0][0]),
+		cancel: cancel,
+	}
+
+	r, err := NewFileReader(src)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err := r.NextRowContext(ctx)
+		require.NoError(t, err)
+	}
+
+	_, err = r.NextRowContext(ctx)
+	assert.True(t, stderrors.Is(err, context.Canceled))
+	assert.Equal(t, 1, r.rowGroupPosition)
+
+	// The row group is loaded again once the source recovers.
+	src.offset = int64(len(file.data))
+
+	rows, err := readRows(r)
+	require.NoError(t, err)
+	require.Len(t, rows, 10)
+	assert.Equal(t, int64(10), rows[0]["id"])
+}
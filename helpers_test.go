@@ -0,0 +1,180 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/hexbee-net/parquet/compression"
+	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/require"
+)
+
+// testColumn is an INT64 column of a test file. The value of a row is its index in
+// the file, multiplied by factor. The optional columns are null for the rows multiple of 3.
+type testColumn struct {
+	name     string
+	optional bool
+	factor   int64
+}
+
+// testFile is a test file and the location of its data pages.
+type testFile struct {
+	data []byte
+	// pages[rowGroup][column][page] is the byte range of the compressed page data.
+	pages [][][][2]int
+}
+
+// corrupt overwrites the data of a page.
+func (f *testFile) corrupt(rowGroup, column, page int) {
+	r := f.pages[rowGroup][column][page]
+	for i := r[0]; i < r[1]; i++ {
+		f.data[i] = 0xff
+	}
+}
+
+// writeTestFile writes a snappy compressed file with the given row groups of rows,
+// each column chunk holding pages data pages.
+func writeTestFile(t *testing.T, cols []testColumn, rowGroups, rows, pages int) *testFile {
+	t.Helper()
+
+	out := &bytes.Buffer{}
+	out.WriteString(magic)
+
+	schema := []*parquet.SchemaElement{{Name: "root", NumChildren: thrift.Int32Ptr(int32(len(cols)))}}
+
+	for _, c := range cols {
+		rep := parquet.FieldRepetitionType_REQUIRED
+		if c.optional {
+			rep = parquet.FieldRepetitionType_OPTIONAL
+		}
+
+		typ := parquet.Type_INT64
+		schema = append(schema, &parquet.SchemaElement{Name: c.name, Type: &typ, RepetitionType: &rep})
+	}
+
+	meta := &parquet.FileMetaData{Version: 1, Schema: schema}
+	file := &testFile{}
+
+	for g := 0; g < rowGroups; g++ {
+		rg := &parquet.RowGroup{NumRows: int64(rows)}
+		locations := make([][][2]int, len(cols))
+
+		for ci, c := range cols {
+			start := int64(out.Len())
+			per := rows / pages
+
+			for p := 0; p < pages; p++ {
+				first := g*rows + p*per
+
+				count := per
+				if p == pages-1 {
+					count = rows - per*(pages-1)
+				}
+
+				raw := &bytes.Buffer{}
+
+				if c.optional {
+					levels, err := encoding.NewHybridEncoder(1)
+					require.NoError(t, err)
+
+					for i := 0; i < count; i++ {
+						require.NoError(t, levels.AppendSingle(boolToLevel((first+i)%3 != 0)))
+					}
+
+					buf := &bytes.Buffer{}
+					require.NoError(t, levels.Write(buf))
+					require.NoError(t, binary.Write(raw, binary.LittleEndian, uint32(buf.Len())))
+					raw.Write(buf.Bytes())
+				}
+
+				for i := 0; i < count; i++ {
+					if c.optional && (first+i)%3 == 0 {
+						continue
+					}
+
+					require.NoError(t, binary.Write(raw, binary.LittleEndian, int64(first+i)*c.factor))
+				}
+
+				data, err := compression.Snappy{}.CompressBlock(raw.Bytes())
+				require.NoError(t, err)
+
+				header := &parquet.PageHeader{
+					Type:                 parquet.PageType_DATA_PAGE,
+					UncompressedPageSize: int32(raw.Len()),
+					CompressedPageSize:   int32(len(data)),
+					DataPageHeader: &parquet.DataPageHeader{
+						NumValues:               int32(count),
+						Encoding:                parquet.Encoding_PLAIN,
+						DefinitionLevelEncoding: parquet.Encoding_RLE,
+						RepetitionLevelEncoding: parquet.Encoding_RLE,
+					},
+				}
+
+				require.NoError(t, writeThrift(header, out))
+
+				locations[ci] = append(locations[ci], [2]int{out.Len(), out.Len() + len(data)})
+				out.Write(data)
+			}
+
+			size := int64(out.Len()) - start
+			rg.Columns = append(rg.Columns, &parquet.ColumnChunk{
+				FileOffset: start,
+				MetaData: &parquet.ColumnMetaData{
+					Type:                  parquet.Type_INT64,
+					Encodings:             []parquet.Encoding{parquet.Encoding_PLAIN, parquet.Encoding_RLE},
+					PathInSchema:          []string{c.name},
+					Codec:                 parquet.CompressionCodec_SNAPPY,
+					NumValues:             int64(rows),
+					TotalUncompressedSize: size,
+					TotalCompressedSize:   size,
+					DataPageOffset:        start,
+				},
+			})
+			rg.TotalByteSize += size
+		}
+
+		meta.RowGroups = append(meta.RowGroups, rg)
+		meta.NumRows += int64(rows)
+		file.pages = append(file.pages, locations)
+	}
+
+	footer := &bytes.Buffer{}
+	require.NoError(t, writeThrift(meta, footer))
+	out.Write(footer.Bytes())
+	require.NoError(t, binary.Write(out, binary.LittleEndian, int32(footer.Len())))
+	out.WriteString(magic)
+
+	file.data = out.Bytes()
+
+	return file
+}
+
+func boolToLevel(b bool) int32 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// readRows reads the rows of a file until the end of the file or the first error.
+func readRows(r *FileReader) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+
+	for {
+		row, err := r.NextRow()
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		if err != nil {
+			return rows, err
+		}
+
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,63 @@
+// Package columnpath formats and parses the dotted paths of the columns, for the
+// packages that can't depend on the schema package.
+package columnpath
+
+import (
+	"strings"
+)
+
+const (
+	separator = '.'
+	escape    = '\\'
+)
+
+// Parse splits a dotted column path into the names of the column and of its parents.
+// A backslash escapes the next character, so that "a\.b.c" is the column "c" of the
+// group "a.b". A trailing backslash is kept as is.
+func Parse(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	var (
+		res  []string
+		part strings.Builder
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == escape && i+1 < len(s):
+			i++
+			part.WriteByte(s[i])
+		case ch == separator:
+			res = append(res, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(ch)
+		}
+	}
+
+	return append(res, part.String())
+}
+
+// Format joins the names of a column path with dots, escaping the dots and the
+// backslashes of the names. It is the reverse of Parse.
+func Format(path []string) string {
+	var b strings.Builder
+
+	for i, name := range path {
+		if i > 0 {
+			b.WriteByte(separator)
+		}
+
+		for j := 0; j < len(name); j++ {
+			if name[j] == separator || name[j] == escape {
+				b.WriteByte(escape)
+			}
+
+			b.WriteByte(name[j])
+		}
+	}
+
+	return b.String()
+}
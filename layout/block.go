@@ -2,11 +2,13 @@ package layout
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/compression"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/parquet"
 )
 
@@ -14,7 +16,7 @@ type blockReader struct {
 	compressors map[parquet.CompressionCodec]compression.BlockCompressor
 }
 
-func (r *blockReader) readBlockData(in io.Reader, codec parquet.CompressionCodec, compressedSize, uncompressedSize int32) (io.Reader, error) {
+func (r *blockReader) readBlockData(ctx context.Context, in io.Reader, codec parquet.CompressionCodec, compressedSize, uncompressedSize int32) (io.Reader, error) {
 	buf, err := ioutil.ReadAll(io.LimitReader(in, int64(compressedSize)))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read block data")
@@ -22,21 +24,26 @@ func (r *blockReader) readBlockData(in io.Reader, codec parquet.CompressionCodec
 
 	if len(buf) != int(compressedSize) {
 		return nil, errors.WithFields(
-			errors.New("invalid size for compressed data"),
+			errs.New(errs.ErrCorruptPage, "invalid size for compressed data"),
 			errors.Fields{
 				"expected": compressedSize,
 				"actual":   len(buf),
 			})
 	}
 
+	// Decompression can be expensive, don't start it if the caller already gave up.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	res, err := r.decompressBlock(buf, codec)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decompress block")
+		return nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to decompress block"))
 	}
 
 	if len(res) != int(uncompressedSize) {
 		return nil, errors.WithFields(
-			errors.New("invalid size for decompressed data"),
+			errs.New(errs.ErrCorruptPage, "invalid size for decompressed data"),
 			errors.Fields{
 				"expected": uncompressedSize,
 				"actual":   len(res),
@@ -50,7 +57,7 @@ func (r *blockReader) compressBlock(block []byte, method parquet.CompressionCode
 	c, ok := r.compressors[method]
 	if !ok {
 		return nil, errors.WithFields(
-			errors.New("compression method not supported"),
+			errs.New(errs.ErrUnsupportedCodec, "compression method not supported"),
 			errors.Fields{
 				"method": method.String(),
 			})
@@ -63,7 +70,7 @@ func (r *blockReader) decompressBlock(block []byte, method parquet.CompressionCo
 	c, ok := r.compressors[method]
 	if !ok {
 		return nil, errors.WithFields(
-			errors.New("compression method not supported"),
+			errs.New(errs.ErrUnsupportedCodec, "compression method not supported"),
 			errors.Fields{
 				"method": method.String(),
 			})
@@ -1,14 +1,19 @@
 package layout
 
 import (
+	"context"
 	"io"
 	"math/bits"
+	"sync"
+	"time"
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/compression"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/parquet"
 	"github.com/hexbee-net/parquet/schema"
+	"github.com/hexbee-net/parquet/source"
 	"github.com/hexbee-net/parquet/types"
 )
 
@@ -19,12 +24,33 @@ type compressorMap map[parquet.CompressionCodec]compression.BlockCompressor
 
 type ChunkReader struct {
 	compressors map[parquet.CompressionCodec]compression.BlockCompressor
+	concurrency int
+	observer    ChunkObserver
+	onPageError PageErrorHandler
 }
 
 func NewChunkReader(compressors map[parquet.CompressionCodec]compression.BlockCompressor) *ChunkReader {
 	return &ChunkReader{compressors: compressors}
 }
 
+// SetConcurrency limits the number of column chunks read at the same time by ReadChunksAt.
+// A value lower than 1 means that all the chunks are read at the same time.
+func (r *ChunkReader) SetConcurrency(n int) {
+	r.concurrency = n
+}
+
+// SetObserver sets the observer notified of the chunks and pages read, nil disables the notifications.
+func (r *ChunkReader) SetObserver(o ChunkObserver) {
+	r.observer = o
+}
+
+// SetPageErrorHandler sets the handler deciding whether the data pages that fail to be read
+// are replaced by a SkippedPage. Only the pages with a valid header in non-repeated columns
+// can be skipped. A nil handler makes any page error fail the chunk.
+func (r *ChunkReader) SetPageErrorHandler(h PageErrorHandler) {
+	r.onPageError = h
+}
+
 func SkipChunk(reader io.Seeker, col *schema.Column, chunk *parquet.ColumnChunk) error {
 	if err := checkColumnChunk(chunk, col); err != nil {
 		return err
@@ -50,11 +76,26 @@ func SkipChunk(reader io.Seeker, col *schema.Column, chunk *parquet.ColumnChunk)
 	return nil
 }
 
+// ReadChunk reads all the pages of a column chunk.
 func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *parquet.ColumnChunk) ([]PageReader, error) {
+	return r.ReadChunkContext(context.Background(), src, col, chunk)
+}
+
+// ReadChunkContext is the same as ReadChunk but the context is passed down
+// to the source reads and checked before each page is decompressed.
+func (r *ChunkReader) ReadChunkContext(ctx context.Context, src io.ReadSeeker, col *schema.Column, chunk *parquet.ColumnChunk) ([]PageReader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if err := checkColumnChunk(chunk, col); err != nil {
 		return nil, err
 	}
 
+	if source.ColumnPath(ctx) == nil {
+		ctx = source.WithColumnPath(ctx, col.Path())
+	}
+
 	offset := chunk.MetaData.DataPageOffset
 	if chunk.MetaData.DictionaryPageOffset != nil {
 		offset = *chunk.MetaData.DictionaryPageOffset
@@ -70,6 +111,7 @@ func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *pa
 	}
 
 	reader := &offsetReader{
+		ctx:    ctx,
 		inner:  src,
 		offset: offset,
 		count:  0,
@@ -78,7 +120,7 @@ func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *pa
 	rDecoder := func(enc parquet.Encoding) (levelDecoder, error) {
 		if enc != parquet.Encoding_RLE {
 			return nil, errors.WithFields(
-				errors.New("encoding not supported for definition and repetition level"),
+				errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for definition and repetition level"),
 				errors.Fields{
 					"encoding": enc.String(),
 				})
@@ -94,7 +136,7 @@ func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *pa
 	dDecoder := func(enc parquet.Encoding) (levelDecoder, error) {
 		if enc != parquet.Encoding_RLE {
 			return nil, errors.WithFields(
-				errors.New("encoding not supported for definition and repetition level"),
+				errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for definition and repetition level"),
 				errors.Fields{
 					"encoding": enc.String(),
 				})
@@ -108,6 +150,7 @@ func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *pa
 		}, nil
 	}
 
+	// The levels are omitted from the pages when their maximum is 0.
 	if col.MaxRepetitionLevel() == 0 {
 		rDecoder = func(parquet.Encoding) (levelDecoder, error) {
 			return &levelDecoderWrapper{
@@ -115,6 +158,9 @@ func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *pa
 				max:     col.MaxRepetitionLevel(),
 			}, nil
 		}
+	}
+
+	if col.MaxDefinitionLevel() == 0 {
 		dDecoder = func(parquet.Encoding) (levelDecoder, error) {
 			return &levelDecoderWrapper{
 				Decoder: encoding.ConstDecoder(0),
@@ -123,23 +169,129 @@ func (r *ChunkReader) ReadChunk(src io.ReadSeeker, col *schema.Column, chunk *pa
 		}
 	}
 
-	return r.readPages(reader, col, chunk.MetaData, dDecoder, rDecoder)
+	start := time.Now()
+
+	pages, err := r.readPages(ctx, reader, col, chunk.MetaData, dDecoder, rDecoder)
+	if err != nil {
+		return nil, errs.WithColumn(err, col.Path())
+	}
+
+	if r.observer != nil {
+		r.observer.ChunkRead(col, chunk, len(pages), time.Since(start))
+	}
+
+	return pages, nil
 }
 
-func (r *ChunkReader) readPages(reader *offsetReader, col *schema.Column, chunkMeta *parquet.ColumnMetaData, dDecoder, rDecoder getLevelDecoderFn) ([]PageReader, error) {
+// ReadChunksAt reads the pages of several column chunks concurrently from src.
+// The pages of each chunk are returned in the same order as the provided columns.
+func (r *ChunkReader) ReadChunksAt(ctx context.Context, src io.ReaderAt, cols []*schema.Column, chunks []*parquet.ColumnChunk) ([][]PageReader, error) {
+	if len(cols) != len(chunks) {
+		return nil, errors.WithFields(
+			errors.New("column and chunk counts mismatch"),
+			errors.Fields{
+				"columns": len(cols),
+				"chunks":  len(chunks),
+			})
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	limit := r.concurrency
+	if limit < 1 || limit > len(cols) {
+		limit = len(cols)
+	}
+
 	var (
-		dictPage *dictPageReader
-		pages    []PageReader
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
 	)
 
-	for {
+	pages := make([][]PageReader, len(cols))
+	sem := make(chan struct{}, limit)
+
+	for i := range cols {
+		sem <- struct{}{}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			colCtx := source.WithColumnPath(ctx, cols[i].Path())
+			cursor := newChunkCursor(colCtx, src, chunks[i])
+
+			p, err := r.ReadChunkContext(colCtx, cursor, cols[i], chunks[i])
+			if err != nil {
+				once.Do(func() {
+					firstErr = errors.WithFields(
+						errors.Wrap(err, "failed to read data chunk"),
+						errors.Fields{
+							"column": cols[i].FlatName(),
+						})
+
+					cancel()
+				})
+
+				return
+			}
+
+			pages[i] = p
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return pages, nil
+}
+
+func (r *ChunkReader) readPages(ctx context.Context, reader *offsetReader, col *schema.Column, chunkMeta *parquet.ColumnMetaData, dDecoder, rDecoder getLevelDecoderFn) ([]PageReader, error) {
+	var (
+		dictPage   *dictPageReader
+		pages      []PageReader
+		ordinal    int
+		pageOffset int64
+	)
+
+	// pageErr sets the location of the page being read in the error.
+	pageErr := func(err error) error {
+		return errs.WithOffset(errs.WithPage(err, ordinal), pageOffset)
+	}
+
+	for ; ; ordinal++ {
 		if chunkMeta.TotalCompressedSize-reader.Count() < 1 {
 			break
 		}
 
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageOffset = reader.offset
+
+		reader.readErr = nil
+
 		pageHeader := &parquet.PageHeader{}
 		if err := readThrift(pageHeader, reader); err != nil {
-			return nil, errors.Wrap(err, "failed to read page header")
+			// A failed or cancelled read isn't a corrupt page.
+			if ctx.Err() != nil {
+				return nil, pageErr(errors.Wrap(ctx.Err(), "failed to read page header"))
+			}
+
+			if reader.readErr != nil {
+				return nil, pageErr(errors.Wrap(reader.readErr, "failed to read page header"))
+			}
+
+			return nil, pageErr(errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to read page header")))
 		}
 
 		var p PageReader
@@ -147,26 +299,30 @@ func (r *ChunkReader) readPages(reader *offsetReader, col *schema.Column, chunkM
 		switch pageHeader.Type { //nolint:exhaustive // supported types only
 		case parquet.PageType_DICTIONARY_PAGE:
 			if dictPage != nil {
-				return nil, errors.New("there should be only one dictionary")
+				return nil, pageErr(errs.New(errs.ErrCorruptPage, "there should be only one dictionary"))
 			}
 
 			dictPage = &dictPageReader{}
 
 			de, err := getDictValuesDecoder(col.Element())
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to get dict value decoder")
+				return nil, pageErr(errors.Wrap(err, "failed to get dict value decoder"))
 			}
 
 			if err := dictPage.init(de, r.compressors); err != nil {
-				return nil, err
+				return nil, pageErr(err)
 			}
 
 			// re-use the value dictionary store
 			dictPage.values = col.ColumnStore().Values.Values
-			if err := dictPage.read(reader, pageHeader, chunkMeta.Codec); err != nil {
-				return nil, err
+
+			start := time.Now()
+			if err := dictPage.read(ctx, reader, pageHeader, chunkMeta.Codec); err != nil {
+				return nil, pageErr(err)
 			}
 
+			r.observePage(col, pageHeader, start)
+
 			// Go to the next data Page.
 			// if we have a DictionaryPageOffset, we should return to DataPageOffset.
 			if chunkMeta.DictionaryPageOffset != nil {
@@ -187,7 +343,7 @@ func (r *ChunkReader) readPages(reader *offsetReader, col *schema.Column, chunkM
 
 		default:
 			return nil, errors.WithFields(
-				errors.New("page type not supported"),
+				pageErr(errs.New(errs.ErrCorruptPage, "page type not supported")),
 				errors.Fields{
 					"page-type": pageHeader.Type.String(),
 				})
@@ -202,20 +358,64 @@ func (r *ChunkReader) readPages(reader *offsetReader, col *schema.Column, chunkM
 			return getValuesDecoder(typ, col.Element(), dictValue)
 		}
 
-		if err := p.init(dDecoder, rDecoder, fn, r.compressors); err != nil {
-			return nil, err
+		p.setLocation(ordinal, pageOffset)
+
+		dataOffset := reader.offset
+		start := time.Now()
+
+		err := p.init(dDecoder, rDecoder, fn, r.compressors)
+		if err == nil {
+			err = p.read(ctx, reader, pageHeader, chunkMeta.Codec)
 		}
 
-		if err := p.read(reader, pageHeader, chunkMeta.Codec); err != nil {
-			return nil, err
+		if err != nil {
+			err = pageErr(err)
+			if ctx.Err() != nil || !r.canSkipPage(col, pageHeader, err) {
+				return nil, err
+			}
+
+			// Go to the next page, the page data may not have been read entirely.
+			if _, err := reader.Seek(dataOffset+int64(pageHeader.CompressedPageSize), io.SeekStart); err != nil {
+				return nil, errors.Wrap(err, "failed to set the read index to the start of the next page")
+			}
+
+			pages = append(pages, &SkippedPage{
+				Ordinal: ordinal,
+				Offset:  pageOffset,
+				Header:  pageHeader,
+				Err:     err,
+				maxD:    col.MaxDefinitionLevel(),
+			})
+
+			continue
 		}
 
+		r.observePage(col, pageHeader, start)
+
 		pages = append(pages, p)
 	}
 
 	return pages, nil
 }
 
+func (r *ChunkReader) canSkipPage(col *schema.Column, header *parquet.PageHeader, err error) bool {
+	if r.onPageError == nil || col.MaxRepetitionLevel() > 0 || header.CompressedPageSize < 0 {
+		return false
+	}
+
+	if header.DataPageHeader == nil && header.DataPageHeaderV2 == nil || PageNumValues(header) < 0 {
+		return false
+	}
+
+	return r.onPageError(err)
+}
+
+func (r *ChunkReader) observePage(col *schema.Column, header *parquet.PageHeader, start time.Time) {
+	if r.observer != nil {
+		r.observer.PageRead(col, header, time.Since(start))
+	}
+}
+
 func checkColumnChunk(chunk *parquet.ColumnChunk, col *schema.Column) error {
 	if chunk.FilePath != nil {
 		return errors.WithFields(
@@ -229,7 +429,7 @@ func checkColumnChunk(chunk *parquet.ColumnChunk, col *schema.Column) error {
 
 	if chunk.MetaData == nil {
 		return errors.WithFields(
-			errors.New("missing meta-data for column"),
+			errs.WithColumn(errs.New(errs.ErrCorruptFooter, "missing meta-data for column"), col.Path()),
 			errors.Fields{
 				"column-index": c,
 			})
@@ -238,7 +438,7 @@ func checkColumnChunk(chunk *parquet.ColumnChunk, col *schema.Column) error {
 	typ := col.Element().GetType()
 	if chunk.MetaData.Type != typ {
 		return errors.WithFields(
-			errors.New("wrong type in column chunk meta-data"),
+			errs.WithColumn(errs.New(errs.ErrSchemaMismatch, "wrong type in column chunk meta-data"), col.Path()),
 			errors.Fields{
 				"expected": typ.String(),
 				"actual":   chunk.MetaData.Type.String(),
@@ -0,0 +1,65 @@
+package layout
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/hexbee-net/parquet/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkReader(t *testing.T) {
+	t.Run("HeaderErrors", TestChunkReader_HeaderErrors)
+}
+
+// failingReader fails all the reads with err.
+type failingReader struct {
+	io.Seeker
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestChunkReader_HeaderErrors(t *testing.T) {
+	t.Parallel()
+
+	s, err := schema.LoadSchema([]*parquet.SchemaElement{
+		{Name: "m", NumChildren: thrift.Int32Ptr(1)},
+		{
+			Name:           "id",
+			Type:           parquet.TypePtr(parquet.Type_INT64),
+			RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED),
+		},
+	})
+	require.NoError(t, err)
+
+	col := s.Columns()[0]
+	chunk := &parquet.ColumnChunk{
+		MetaData: &parquet.ColumnMetaData{
+			Type:                parquet.Type_INT64,
+			Codec:               parquet.CompressionCodec_UNCOMPRESSED,
+			TotalCompressedSize: 16,
+		},
+	}
+
+	r := NewChunkReader(nil)
+
+	// The header isn't valid thrift.
+	_, err = r.ReadChunk(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)), col, chunk)
+	assert.True(t, stderrors.Is(err, errs.ErrCorruptPage))
+
+	// The source fails: the page isn't reported as corrupt.
+	errRead := stderrors.New("connection reset")
+
+	_, err = r.ReadChunk(&failingReader{Seeker: bytes.NewReader(nil), err: errRead}, col, chunk)
+	assert.True(t, stderrors.Is(err, errRead))
+	assert.False(t, stderrors.Is(err, errs.ErrCorruptPage))
+}
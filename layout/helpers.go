@@ -1,12 +1,15 @@
 package layout
 
 import (
+	"context"
 	"io"
 	"math/bits"
 
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/hexbee-net/parquet/source"
 )
 
 type thriftReader interface {
@@ -36,16 +39,24 @@ func writeThrift(tr thriftWriter, w io.Writer) error {
 // /////////////////////////////////////////////////////////////////////////////
 
 type offsetReader struct {
+	ctx    context.Context
 	inner  io.ReadSeeker
 	offset int64
 	count  int64
+	// readErr is the last error of the inner reader other than io.EOF: the thrift
+	// decoder doesn't keep the cause of its errors.
+	readErr error
 }
 
 func (r *offsetReader) Read(p []byte) (int, error) {
-	n, err := r.inner.Read(p)
+	n, err := source.ReadContext(r.ctx, r.inner, p)
 	r.offset += int64(n)
 	r.count += int64(n)
 
+	if err != nil && err != io.EOF {
+		r.readErr = err
+	}
+
 	return n, err
 }
 
@@ -65,6 +76,87 @@ func (r *offsetReader) Count() int64 {
 
 // /////////////////////////////////////////////////////////////////////////////
 
+// readerAtCursor turns an io.ReaderAt into an independent io.ReadSeeker, so that
+// several column chunks can be read concurrently from the same source.
+// The small reads (page headers, levels...) are served from its own read-ahead
+// window, the windows can't be shared between the concurrent cursors.
+type readerAtCursor struct {
+	ctx       context.Context
+	inner     io.ReaderAt
+	offset    int64
+	readAhead *source.ReadAhead
+}
+
+// newChunkCursor creates a cursor reading a column chunk, whose read-ahead window
+// is large enough to hold the whole chunk, up to source.DefaultReadAheadSize.
+func newChunkCursor(ctx context.Context, src io.ReaderAt, chunk *parquet.ColumnChunk) *readerAtCursor {
+	size := 0
+
+	// The chunks of a RangeBuffer are already in memory.
+	if _, ok := src.(*RangeBuffer); !ok && chunk.GetMetaData() != nil {
+		size = source.DefaultReadAheadSize
+		if n := chunk.MetaData.TotalCompressedSize; n < int64(size) {
+			size = int(n)
+		}
+	}
+
+	return &readerAtCursor{
+		ctx:       ctx,
+		inner:     src,
+		readAhead: source.NewReadAhead(size),
+	}
+}
+
+func (r *readerAtCursor) Read(p []byte) (int, error) {
+	n, err := r.readAhead.ReadAt(r.ctx, p, r.offset, r.fetch)
+	r.offset += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (r *readerAtCursor) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		sized, ok := r.inner.(source.ReaderAt)
+		if !ok {
+			return 0, errors.New("seeking from the end requires the source size")
+		}
+
+		offset += sized.Size()
+	default:
+		return 0, errors.WithFields(
+			errors.New("invalid whence"),
+			errors.Fields{
+				"whence": whence,
+			})
+	}
+
+	if offset < 0 {
+		return 0, errors.WithFields(
+			errors.New("negative offset"),
+			errors.Fields{
+				"offset": offset,
+			})
+	}
+
+	r.offset = offset
+
+	return r.offset, nil
+}
+
+func (r *readerAtCursor) fetch(ctx context.Context, p []byte, off int64) (int, error) {
+	return source.ReadAtContext(ctx, r.inner, p, off)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+
 func decodePackedArray(d levelDecoder, count int) (*encoding.PackedArray, int, error) {
 	array := &encoding.PackedArray{}
 	notNull := 0 // Counting not nulls only good for dLevels
@@ -0,0 +1,138 @@
+package layout
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderAtCursor(t *testing.T) {
+	t.Run("Read", TestReaderAtCursor_Read)
+	t.Run("Seek", TestReaderAtCursor_Seek)
+	t.Run("ReadAhead", TestReaderAtCursor_ReadAhead)
+}
+
+// countingReaderAt counts the positioned reads of a reader without Size.
+type countingReaderAt struct {
+	r     *bytes.Reader
+	calls int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.calls++
+	return c.r.ReadAt(p, off)
+}
+
+func testChunk(offset, size int64) *parquet.ColumnChunk {
+	return &parquet.ColumnChunk{
+		MetaData: &parquet.ColumnMetaData{
+			DataPageOffset:      offset,
+			TotalCompressedSize: size,
+		},
+	}
+}
+
+func TestReaderAtCursor_Read(t *testing.T) {
+	t.Parallel()
+
+	c := &readerAtCursor{ctx: context.Background(), inner: bytes.NewReader([]byte("0123456789"))}
+
+	p := make([]byte, 4)
+
+	for _, expected := range []string{"0123", "4567", "89"} {
+		n, err := c.Read(p)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(p[:n]))
+	}
+
+	_, err := c.Read(p)
+	assert.Equal(t, io.EOF, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c = &readerAtCursor{ctx: ctx, inner: bytes.NewReader([]byte("0123456789"))}
+	_, err = c.Read(p)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestReaderAtCursor_Seek(t *testing.T) {
+	t.Parallel()
+
+	c := &readerAtCursor{ctx: context.Background(), inner: bytes.NewReader([]byte("0123456789"))}
+
+	for _, tt := range []struct {
+		offset   int64
+		whence   int
+		expected int64
+	}{
+		{offset: 4, whence: io.SeekStart, expected: 4},
+		{offset: 2, whence: io.SeekCurrent, expected: 6},
+		{offset: -3, whence: io.SeekEnd, expected: 7},
+	} {
+		pos, err := c.Seek(tt.offset, tt.whence)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, pos)
+	}
+
+	p := make([]byte, 2)
+	_, err := c.Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, "78", string(p))
+
+	_, err = c.Seek(-10, io.SeekCurrent)
+	assert.Error(t, err)
+
+	_, err = c.Seek(0, 3)
+	assert.Error(t, err)
+
+	// The source has no size.
+	c = &readerAtCursor{ctx: context.Background(), inner: &countingReaderAt{r: bytes.NewReader(nil)}}
+	_, err = c.Seek(0, io.SeekEnd)
+	assert.Error(t, err)
+}
+
+func TestReaderAtCursor_ReadAhead(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	src := &countingReaderAt{r: bytes.NewReader(data)}
+	c := newChunkCursor(context.Background(), src, testChunk(10, 50))
+
+	_, err := c.Seek(10, io.SeekStart)
+	require.NoError(t, err)
+
+	p := make([]byte, 5)
+
+	for off := 10; off < 60; off += 5 {
+		n, err := c.Read(p)
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+		assert.Equal(t, data[off:off+5], p)
+	}
+
+	// The window holds the whole chunk.
+	assert.Equal(t, 1, src.calls)
+
+	// Reading past the chunk fetches a new window.
+	_, err = c.Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, data[60:65], p)
+	assert.Equal(t, 2, src.calls)
+
+	// The chunks of a RangeBuffer are not buffered again.
+	buf, err := FetchRanges(context.Background(), bytes.NewReader(data), []ByteRange{{Offset: 10, Length: 50}}, 1)
+	require.NoError(t, err)
+
+	c = newChunkCursor(context.Background(), buf, testChunk(10, 50))
+	assert.Nil(t, c.readAhead)
+}
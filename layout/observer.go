@@ -0,0 +1,46 @@
+package layout
+
+import (
+	"time"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/hexbee-net/parquet/schema"
+)
+
+// ChunkObserver receives the events of the column chunks read by a ChunkReader.
+// Its methods may be called concurrently for different column chunks.
+type ChunkObserver interface {
+	// ChunkRead is called once all the pages of a column chunk are read.
+	ChunkRead(col *schema.Column, chunk *parquet.ColumnChunk, pages int, duration time.Duration)
+	// PageRead is called for each page read, dictionary pages included, once the
+	// page data is decompressed and its decoders are initialized.
+	PageRead(col *schema.Column, header *parquet.PageHeader, duration time.Duration)
+}
+
+// PageEncoding returns the encoding of the values of a page.
+func PageEncoding(header *parquet.PageHeader) parquet.Encoding {
+	switch {
+	case header.DataPageHeader != nil:
+		return header.DataPageHeader.Encoding
+	case header.DataPageHeaderV2 != nil:
+		return header.DataPageHeaderV2.Encoding
+	case header.DictionaryPageHeader != nil:
+		return header.DictionaryPageHeader.Encoding
+	default:
+		return parquet.Encoding_PLAIN
+	}
+}
+
+// PageNumValues returns the number of values of a page.
+func PageNumValues(header *parquet.PageHeader) int32 {
+	switch {
+	case header.DataPageHeader != nil:
+		return header.DataPageHeader.NumValues
+	case header.DataPageHeaderV2 != nil:
+		return header.DataPageHeaderV2.NumValues
+	case header.DictionaryPageHeader != nil:
+		return header.DictionaryPageHeader.NumValues
+	default:
+		return 0
+	}
+}
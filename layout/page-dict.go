@@ -1,9 +1,11 @@
 package layout
 
 import (
+	"context"
 	"io"
 
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/parquet"
 	"github.com/hexbee-net/parquet/types"
 )
@@ -16,7 +18,7 @@ type dictPageReader struct {
 
 func (r *dictPageReader) init(decoder types.ValuesDecoder, compressors compressorMap) error {
 	if decoder == nil {
-		return errors.New("dictionary page without dictionary value encoder")
+		return errs.New(errs.ErrUnsupportedType, "dictionary page without dictionary value encoder")
 	}
 
 	r.valuesDecoder = decoder
@@ -25,14 +27,14 @@ func (r *dictPageReader) init(decoder types.ValuesDecoder, compressors compresso
 	return nil
 }
 
-func (r *dictPageReader) read(reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) error {
+func (r *dictPageReader) read(ctx context.Context, reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) error {
 	if pageHeader.DictionaryPageHeader == nil {
-		return errors.New("missing dictionary page header")
+		return errs.New(errs.ErrCorruptPage, "missing dictionary page header")
 	}
 
 	if pageHeader.DictionaryPageHeader.NumValues < 0 {
 		return errors.WithFields(
-			errors.New("negative NumValues in DICTIONARY_PAGE"),
+			errs.New(errs.ErrCorruptPage, "negative NumValues in DICTIONARY_PAGE"),
 			errors.Fields{
 				"num-values": pageHeader.DictionaryPageHeader.NumValues,
 			},
@@ -41,7 +43,7 @@ func (r *dictPageReader) read(reader io.Reader, pageHeader *parquet.PageHeader,
 
 	if pageHeader.DictionaryPageHeader.Encoding != parquet.Encoding_PLAIN && pageHeader.DictionaryPageHeader.Encoding != parquet.Encoding_PLAIN_DICTIONARY {
 		return errors.WithFields(
-			errors.New("only Encoding_PLAIN and Encoding_PLAIN_DICTIONARY are supported for dict values encoder"),
+			errs.New(errs.ErrUnsupportedEncoding, "only Encoding_PLAIN and Encoding_PLAIN_DICTIONARY are supported for dict values encoder"),
 			errors.Fields{
 				"encoding": pageHeader.DictionaryPageHeader.Encoding,
 			},
@@ -51,7 +53,7 @@ func (r *dictPageReader) read(reader io.Reader, pageHeader *parquet.PageHeader,
 	r.valuesCount = pageHeader.DictionaryPageHeader.NumValues
 	r.pageHeader = pageHeader
 
-	dataReader, err := r.readPageBlock(reader, codec, pageHeader.GetCompressedPageSize(), pageHeader.GetUncompressedPageSize())
+	dataReader, err := r.readPageBlock(ctx, reader, codec, pageHeader.GetCompressedPageSize(), pageHeader.GetUncompressedPageSize())
 	if err != nil {
 		return err
 	}
@@ -63,13 +65,13 @@ func (r *dictPageReader) read(reader io.Reader, pageHeader *parquet.PageHeader,
 	r.values = r.values[:int(r.valuesCount)]
 
 	if err := r.valuesDecoder.Init(dataReader); err != nil {
-		return errors.WithStack(err)
+		return errs.Wrap(errs.ErrCorruptPage, errors.WithStack(err))
 	}
 
 	// no error is accepted here, even EOF
 	if n, err := r.valuesDecoder.DecodeValues(r.values); err != nil {
 		return errors.WithFields(
-			errors.New("unexpected number of values"),
+			errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "unexpected number of values")),
 			errors.Fields{
 				"expected": r.valuesCount,
 				"actual":   n,
@@ -88,7 +90,7 @@ func getDictValuesDecoder(typ *parquet.SchemaElement) (types.ValuesDecoder, erro
 
 	case parquet.Type_FIXED_LEN_BYTE_ARRAY:
 		if typ.TypeLength == nil {
-			return nil, errors.Errorf("type %s with nil type len", typ)
+			return nil, errs.Newf(errs.ErrSchemaMismatch, "type %s with nil type len", typ)
 		}
 
 		return &types.ByteArrayPlainDecoder{Length: int(*typ.TypeLength)}, nil
@@ -134,7 +136,7 @@ func getDictValuesDecoder(typ *parquet.SchemaElement) (types.ValuesDecoder, erro
 	}
 
 	return nil, errors.WithFields(
-		errors.New("type not supported for dict value encoder"),
+		errs.New(errs.ErrUnsupportedType, "type not supported for dict value encoder"),
 		errors.Fields{
 			"type": typ,
 		})
@@ -171,7 +173,7 @@ func getValuesDecoder(pageEncoding parquet.Encoding, typ *parquet.SchemaElement,
 	case parquet.Type_FIXED_LEN_BYTE_ARRAY:
 		if typ.TypeLength == nil {
 			return nil, errors.WithFields(
-				errors.New("type with nil type length"),
+				errs.New(errs.ErrSchemaMismatch, "type with nil type length"),
 				errors.Fields{
 					"type": typ.Type,
 				})
@@ -181,7 +183,7 @@ func getValuesDecoder(pageEncoding parquet.Encoding, typ *parquet.SchemaElement,
 
 	default:
 		return nil, errors.WithFields(
-			errors.New("unsupported type"),
+			errs.New(errs.ErrUnsupportedType, "unsupported type"),
 			errors.Fields{
 				"type": typ.Type,
 			})
@@ -198,7 +200,7 @@ func getBooleanValuesDecoder(pageEncoding parquet.Encoding, dictValues []interfa
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for boolean"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for boolean"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -227,7 +229,7 @@ func getInt32ValuesDecoder(pageEncoding parquet.Encoding, typ *parquet.SchemaEle
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for int32"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for int32"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -256,7 +258,7 @@ func getInt64ValuesDecoder(pageEncoding parquet.Encoding, typ *parquet.SchemaEle
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for int32"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for int32"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -271,7 +273,7 @@ func getInt96ValuesDecoder(pageEncoding parquet.Encoding, dictValues []interface
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for int96"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for int96"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -286,7 +288,7 @@ func getFloatValuesDecoder(pageEncoding parquet.Encoding, dictValues []interface
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for float"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for float"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -301,7 +303,7 @@ func getDoubleValuesDecoder(pageEncoding parquet.Encoding, dictValues []interfac
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for double"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for double"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -320,7 +322,7 @@ func getByteArrayValuesDecoder(pageEncoding parquet.Encoding, dictValues []inter
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for binary"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for binary"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
@@ -337,7 +339,7 @@ func getFixedLenByteArrayValuesDecoder(pageEncoding parquet.Encoding, length int
 		return &types.DictDecoder{Values: dictValues}, nil
 	default:
 		return nil, errors.WithFields(
-			errors.New("encoding not supported for fixed_len_byte_array"),
+			errs.New(errs.ErrUnsupportedEncoding, "encoding not supported for fixed_len_byte_array"),
 			errors.Fields{
 				"encoding": pageEncoding.String(),
 			})
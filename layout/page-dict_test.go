@@ -0,0 +1,40 @@
+package layout
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesDecoder(t *testing.T) {
+	t.Run("Errors", TestValuesDecoder_Errors)
+}
+
+func TestValuesDecoder_Errors(t *testing.T) {
+	t.Parallel()
+
+	flba := &parquet.SchemaElement{Type: parquet.TypePtr(parquet.Type_FIXED_LEN_BYTE_ARRAY)}
+	boolean := &parquet.SchemaElement{Type: parquet.TypePtr(parquet.Type_BOOLEAN)}
+	unknown := &parquet.SchemaElement{Type: parquet.TypePtr(parquet.Type(42))}
+
+	_, err := getValuesDecoder(parquet.Encoding_PLAIN, flba, nil)
+	assert.True(t, stderrors.Is(err, errs.ErrSchemaMismatch))
+
+	_, err = getDictValuesDecoder(flba)
+	assert.True(t, stderrors.Is(err, errs.ErrSchemaMismatch))
+
+	_, err = getValuesDecoder(parquet.Encoding_PLAIN, unknown, nil)
+	assert.True(t, stderrors.Is(err, errs.ErrUnsupportedType))
+
+	_, err = getDictValuesDecoder(boolean)
+	assert.True(t, stderrors.Is(err, errs.ErrUnsupportedType))
+
+	_, err = getValuesDecoder(parquet.Encoding_BIT_PACKED, boolean, nil)
+	assert.True(t, stderrors.Is(err, errs.ErrUnsupportedEncoding))
+
+	err = (&dictPageReader{}).init(nil, nil)
+	assert.True(t, stderrors.Is(err, errs.ErrUnsupportedType))
+}
@@ -1,10 +1,12 @@
 package layout
 
 import (
+	"context"
 	"io"
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/parquet"
 )
 
@@ -37,20 +39,20 @@ func (r *dataPageReaderV1) init(dDecoderFn, rDecoderFn getLevelDecoderFn, valueD
 	return nil
 }
 
-func (r *dataPageReaderV1) read(reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) error {
+func (r *dataPageReaderV1) read(ctx context.Context, reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) error {
 	if pageHeader.DataPageHeader == nil {
-		return errors.New("missing data page header")
+		return errs.New(errs.ErrCorruptPage, "missing data page header")
 	}
 
 	if r.valuesCount = pageHeader.DataPageHeader.NumValues; r.valuesCount < 0 {
 		return errors.WithFields(
-			errors.New("negative NumValues in DATA_PAGE"),
+			errs.New(errs.ErrCorruptPage, "negative NumValues in DATA_PAGE"),
 			errors.Fields{
 				"num-values": r.valuesCount,
 			})
 	}
 
-	dataReader, err := r.readPageBlock(reader, codec, pageHeader.GetCompressedPageSize(), pageHeader.GetUncompressedPageSize())
+	dataReader, err := r.readPageBlock(ctx, reader, codec, pageHeader.GetCompressedPageSize(), pageHeader.GetUncompressedPageSize())
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -60,21 +62,21 @@ func (r *dataPageReaderV1) read(reader io.Reader, pageHeader *parquet.PageHeader
 
 	if r.valuesDecoder, err = r.valueDecoderFn(r.encoding); err != nil {
 		return errors.WithFields(
-			errors.New("failed to get value decoder for encoding"),
+			errs.Wrap(errs.ErrUnsupportedEncoding, errors.Wrap(err, "failed to get value decoder for encoding")),
 			errors.Fields{
 				"encoding": r.encoding,
 			})
 	}
 
 	if err := r.repetitionDecoder.InitSize(dataReader); err != nil {
-		return errors.Wrap(err, "failed to initialize repetition decoder")
+		return errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to initialize repetition decoder"))
 	}
 
 	if err := r.definitionDecoder.InitSize(dataReader); err != nil {
-		return errors.Wrap(err, "failed to initialize definition decoder")
+		return errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to initialize definition decoder"))
 	}
 
-	return r.valuesDecoder.Init(dataReader)
+	return errs.Wrap(errs.ErrCorruptPage, r.valuesDecoder.Init(dataReader))
 }
 
 func (r *dataPageReaderV1) ReadValues(values []interface{}) (n int, dLevel *encoding.PackedArray, rLevel *encoding.PackedArray, err error) {
@@ -89,20 +91,20 @@ func (r *dataPageReaderV1) ReadValues(values []interface{}) (n int, dLevel *enco
 
 	rLevel, _, err = decodePackedArray(r.repetitionDecoder, size)
 	if err != nil {
-		return 0, nil, nil, errors.Wrap(err, "read repetition levels failed")
+		return 0, nil, nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "read repetition levels failed"))
 	}
 
 	var notNull int
 
 	dLevel, notNull, err = decodePackedArray(r.definitionDecoder, size)
 	if err != nil {
-		return 0, nil, nil, errors.Wrap(err, "read definition levels failed")
+		return 0, nil, nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "read definition levels failed"))
 	}
 
 	if notNull != 0 {
 		if n, err := r.valuesDecoder.DecodeValues(values[:notNull]); err != nil {
 			return 0, nil, nil, errors.WithFields(
-				errors.New("read values from page failed"),
+				errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "read values from page failed")),
 				errors.Fields{
 					"expected": notNull,
 					"actual":   n,
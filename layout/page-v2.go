@@ -2,10 +2,12 @@ package layout
 
 import (
 	"bytes"
+	"context"
 	"io"
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/parquet"
 )
 
@@ -39,16 +41,16 @@ func (r *dataPageReaderV2) init(dDecoder, rDecoder getLevelDecoderFn, valueDecod
 	return nil
 }
 
-func (r *dataPageReaderV2) read(reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) (err error) {
+func (r *dataPageReaderV2) read(ctx context.Context, reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) (err error) {
 	// 1- Uncompressed size is affected by the level lens.
 	// 2- In page V2 the rle size is in header, not in level stream
 	if pageHeader.DataPageHeaderV2 == nil {
-		return errors.New("missing data page header")
+		return errs.New(errs.ErrCorruptPage, "missing data page header")
 	}
 
 	if r.valuesCount = pageHeader.DataPageHeaderV2.NumValues; r.valuesCount < 0 {
 		return errors.WithFields(
-			errors.New("negative NumValues in DATA_PAGE_V2"),
+			errs.New(errs.ErrCorruptPage, "negative NumValues in DATA_PAGE_V2"),
 			errors.Fields{
 				"num-values": r.valuesCount,
 			})
@@ -56,7 +58,7 @@ func (r *dataPageReaderV2) read(reader io.Reader, pageHeader *parquet.PageHeader
 
 	if pageHeader.DataPageHeaderV2.RepetitionLevelsByteLength < 0 {
 		return errors.WithFields(
-			errors.New("invalid RepetitionLevelsByteLength"),
+			errs.New(errs.ErrCorruptPage, "invalid RepetitionLevelsByteLength"),
 			errors.Fields{
 				"value": pageHeader.DataPageHeaderV2.RepetitionLevelsByteLength,
 			})
@@ -64,7 +66,7 @@ func (r *dataPageReaderV2) read(reader io.Reader, pageHeader *parquet.PageHeader
 
 	if pageHeader.DataPageHeaderV2.DefinitionLevelsByteLength < 0 {
 		return errors.WithFields(
-			errors.New("invalid DefinitionLevelsByteLength"),
+			errs.New(errs.ErrCorruptPage, "invalid DefinitionLevelsByteLength"),
 			errors.Fields{
 				"value": pageHeader.DataPageHeaderV2.DefinitionLevelsByteLength,
 			})
@@ -74,7 +76,7 @@ func (r *dataPageReaderV2) read(reader io.Reader, pageHeader *parquet.PageHeader
 	r.pageHeader = pageHeader
 
 	if r.valuesDecoder, err = r.valueDecoderFn(r.encoding); err != nil {
-		return err
+		return errs.Wrap(errs.ErrUnsupportedEncoding, err)
 	}
 
 	// Its safe to call this {r,d}Decoder later, since the stream they operate on are in memory
@@ -86,28 +88,28 @@ func (r *dataPageReaderV2) read(reader io.Reader, pageHeader *parquet.PageHeader
 
 		n, err := io.ReadFull(reader, data)
 		if err != nil {
-			return errors.Wrapf(err, "need to read %d byte but there was only %d byte", levelsSize, n)
+			return errs.Wrap(errs.ErrCorruptPage, errors.Wrapf(err, "need to read %d byte but there was only %d byte", levelsSize, n))
 		}
 
 		if pageHeader.DataPageHeaderV2.RepetitionLevelsByteLength > 0 {
 			if err := r.repetitionDecoder.Init(bytes.NewReader(data[:int(pageHeader.DataPageHeaderV2.RepetitionLevelsByteLength)])); err != nil {
-				return errors.Wrap(err, "failed to initialize repetition decoder")
+				return errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to initialize repetition decoder"))
 			}
 		}
 
 		if pageHeader.DataPageHeaderV2.DefinitionLevelsByteLength > 0 {
 			if err := r.definitionDecoder.Init(bytes.NewReader(data[int(pageHeader.DataPageHeaderV2.RepetitionLevelsByteLength):])); err != nil {
-				return errors.Wrap(err, "failed to initialize definition decoder")
+				return errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to initialize definition decoder"))
 			}
 		}
 	}
 
-	dataReader, err := r.readPageBlock(reader, codec, pageHeader.GetCompressedPageSize()-levelsSize, pageHeader.GetUncompressedPageSize()-levelsSize)
+	dataReader, err := r.readPageBlock(ctx, reader, codec, pageHeader.GetCompressedPageSize()-levelsSize, pageHeader.GetUncompressedPageSize()-levelsSize)
 	if err != nil {
 		return err
 	}
 
-	return r.valuesDecoder.Init(dataReader)
+	return errs.Wrap(errs.ErrCorruptPage, r.valuesDecoder.Init(dataReader))
 }
 
 func (r *dataPageReaderV2) ReadValues(values []interface{}) (n int, dLevel *encoding.PackedArray, rLevel *encoding.PackedArray, err error) {
@@ -122,20 +124,20 @@ func (r *dataPageReaderV2) ReadValues(values []interface{}) (n int, dLevel *enco
 
 	rLevel, _, err = decodePackedArray(r.repetitionDecoder, size)
 	if err != nil {
-		return 0, nil, nil, errors.Wrap(err, "read repetition levels failed")
+		return 0, nil, nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "read repetition levels failed"))
 	}
 
 	var notNull int
 
 	dLevel, notNull, err = decodePackedArray(r.definitionDecoder, size)
 	if err != nil {
-		return 0, nil, nil, errors.Wrap(err, "read definition levels failed")
+		return 0, nil, nil, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "read definition levels failed"))
 	}
 
 	if notNull != 0 {
 		if n, err := r.valuesDecoder.DecodeValues(values[:notNull]); err != nil {
 			return 0, nil, nil, errors.WithFields(
-				errors.New("read values from page failed"),
+				errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "read values from page failed")),
 				errors.Fields{
 					"expected": notNull,
 					"actual":   n,
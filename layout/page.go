@@ -1,10 +1,12 @@
 package layout
 
 import (
+	"context"
 	"io"
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 	"github.com/hexbee-net/parquet/parquet"
 	"github.com/hexbee-net/parquet/schema"
 	"github.com/hexbee-net/parquet/types"
@@ -13,11 +15,16 @@ import (
 // PageReader is an internal interface used only internally to read pages.
 type PageReader interface {
 	init(dDecoder, rDecoder getLevelDecoderFn, values getValueDecoderFn, compressors compressorMap) error
-	read(reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) error
+	read(ctx context.Context, reader io.Reader, pageHeader *parquet.PageHeader, codec parquet.CompressionCodec) error
 
 	ReadValues(values []interface{}) (n int, dLevel *encoding.PackedArray, rLevel *encoding.PackedArray, err error)
 
 	NumValues() int32
+
+	// Location returns the ordinal of the page in its column chunk, the dictionary page
+	// included, and the offset of its header in the file.
+	Location() (ordinal int, offset int64)
+	setLocation(ordinal int, offset int64)
 }
 
 // PageWriter is an internal interface used only internally to write pages.
@@ -33,17 +40,27 @@ type page struct {
 	valuesCount   int32
 	valuesDecoder types.ValuesDecoder
 	blockReader   blockReader
+	ordinal       int
+	offset        int64
+}
+
+func (p *page) Location() (ordinal int, offset int64) {
+	return p.ordinal, p.offset
+}
+
+func (p *page) setLocation(ordinal int, offset int64) {
+	p.ordinal, p.offset = ordinal, offset
 }
 
-func (p *page) readPageBlock(in io.Reader, codec parquet.CompressionCodec, compressedSize, uncompressedSize int32) (io.Reader, error) {
+func (p *page) readPageBlock(ctx context.Context, in io.Reader, codec parquet.CompressionCodec, compressedSize, uncompressedSize int32) (io.Reader, error) {
 	if compressedSize < 0 || uncompressedSize < 0 {
 		return nil, errors.WithFields(
-			errors.New("invalid page data size"),
+			errs.New(errs.ErrCorruptPage, "invalid page data size"),
 			errors.Fields{
 				"compressed-size":   compressedSize,
 				"uncompressed-size": uncompressedSize,
 			})
 	}
 
-	return p.blockReader.readBlockData(in, codec, compressedSize, uncompressedSize)
+	return p.blockReader.readBlockData(ctx, in, codec, compressedSize, uncompressedSize)
 }
@@ -0,0 +1,275 @@
+package layout
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const (
+	// DefaultMaxGap is the default largest gap between two byte ranges for them to be fetched in one request.
+	DefaultMaxGap = 64 * 1024
+	// DefaultMaxRangeSize is the default largest size of a single request.
+	DefaultMaxRangeSize = 32 * 1024 * 1024
+)
+
+// ByteRange is a contiguous range of bytes in a source.
+type ByteRange struct {
+	Offset int64
+	Length int64
+}
+
+// End returns the offset of the first byte after the range.
+func (r ByteRange) End() int64 {
+	return r.Offset + r.Length
+}
+
+// PlannerOptions controls how the byte ranges of column chunks are turned into requests.
+type PlannerOptions struct {
+	// MaxGap is the largest number of unused bytes between two ranges for them
+	// to be merged into a single request.
+	MaxGap int64
+	// MaxRangeSize caps the size of a single request. Larger ranges are split.
+	// A value lower than 1 means no limit.
+	MaxRangeSize int64
+	// Concurrency limits the number of requests issued at the same time.
+	// A value lower than 1 means that all the requests are issued at the same time.
+	Concurrency int
+}
+
+// DefaultPlannerOptions returns the options used by default by the I/O planner.
+func DefaultPlannerOptions() PlannerOptions {
+	return PlannerOptions{
+		MaxGap:       DefaultMaxGap,
+		MaxRangeSize: DefaultMaxRangeSize,
+	}
+}
+
+// ChunkRange returns the byte range occupied by a column chunk in the file.
+func ChunkRange(chunk *parquet.ColumnChunk) ByteRange {
+	offset := chunk.MetaData.DataPageOffset
+	if chunk.MetaData.DictionaryPageOffset != nil {
+		offset = *chunk.MetaData.DictionaryPageOffset
+	}
+
+	return ByteRange{
+		Offset: offset,
+		Length: chunk.MetaData.TotalCompressedSize,
+	}
+}
+
+// PlanChunkRanges computes the requests needed to read the provided column chunks.
+func PlanChunkRanges(chunks []*parquet.ColumnChunk, opts PlannerOptions) []ByteRange {
+	ranges := make([]ByteRange, 0, len(chunks))
+
+	for _, chunk := range chunks {
+		if chunk.MetaData == nil {
+			continue
+		}
+
+		ranges = append(ranges, ChunkRange(chunk))
+	}
+
+	return PlanRanges(ranges, opts)
+}
+
+// PlanRanges sorts the provided ranges, merges the ones closer than opts.MaxGap and
+// splits the result so that no range is larger than opts.MaxRangeSize.
+func PlanRanges(ranges []ByteRange, opts PlannerOptions) []ByteRange {
+	sorted := make([]ByteRange, 0, len(ranges))
+
+	for _, r := range ranges {
+		if r.Length > 0 {
+			sorted = append(sorted, r)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	var merged []ByteRange
+
+	for _, r := range sorted {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+
+			if r.Offset-last.End() <= opts.MaxGap {
+				end := r.End()
+				if end < last.End() {
+					end = last.End()
+				}
+
+				if opts.MaxRangeSize < 1 || end-last.Offset <= opts.MaxRangeSize || r.Offset < last.End() {
+					last.Length = end - last.Offset
+					continue
+				}
+			}
+		}
+
+		merged = append(merged, r)
+	}
+
+	if opts.MaxRangeSize < 1 {
+		return merged
+	}
+
+	ret := make([]ByteRange, 0, len(merged))
+
+	for _, r := range merged {
+		for r.Length > opts.MaxRangeSize {
+			ret = append(ret, ByteRange{Offset: r.Offset, Length: opts.MaxRangeSize})
+			r.Offset += opts.MaxRangeSize
+			r.Length -= opts.MaxRangeSize
+		}
+
+		ret = append(ret, r)
+	}
+
+	return ret
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+
+// RangeBuffer holds the content of prefetched byte ranges of a source and
+// serves reads from them. Reads outside of the prefetched ranges fail.
+type RangeBuffer struct {
+	ranges []ByteRange
+	data   [][]byte
+	offset int64
+}
+
+// FetchRanges reads the provided ranges from src, concurrently, and returns
+// a RangeBuffer serving their content.
+// The ranges must be sorted and must not overlap, as returned by PlanRanges.
+func FetchRanges(ctx context.Context, src io.ReaderAt, ranges []ByteRange, concurrency int) (*RangeBuffer, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if concurrency < 1 || concurrency > len(ranges) {
+		concurrency = len(ranges)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	buf := &RangeBuffer{
+		ranges: ranges,
+		data:   make([][]byte, len(ranges)),
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	for i := range ranges {
+		sem <- struct{}{}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			data := make([]byte, ranges[i].Length)
+
+			n, err := source.ReadAtContext(ctx, src, data, ranges[i].Offset)
+			if err == io.EOF && n == len(data) {
+				err = nil
+			}
+
+			if err != nil {
+				once.Do(func() {
+					firstErr = errors.WithFields(
+						errors.Wrap(err, "failed to fetch byte range"),
+						errors.Fields{
+							"offset": ranges[i].Offset,
+							"length": ranges[i].Length,
+						})
+
+					cancel()
+				})
+
+				return
+			}
+
+			buf.data[i] = data
+		}(i)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return buf, nil
+}
+
+// Ranges returns the byte ranges held by the buffer.
+func (b *RangeBuffer) Ranges() []ByteRange {
+	return b.ranges
+}
+
+func (b *RangeBuffer) ReadAt(p []byte, off int64) (n int, err error) {
+	for n < len(p) {
+		i := sort.Search(len(b.ranges), func(i int) bool {
+			return b.ranges[i].End() > off
+		})
+
+		if i == len(b.ranges) || b.ranges[i].Offset > off {
+			return n, errors.WithFields(
+				errors.New("offset was not prefetched"),
+				errors.Fields{
+					"offset": off,
+				})
+		}
+
+		c := copy(p[n:], b.data[i][off-b.ranges[i].Offset:])
+		n += c
+		off += int64(c)
+	}
+
+	return n, nil
+}
+
+func (b *RangeBuffer) Read(p []byte) (n int, err error) {
+	n, err = b.ReadAt(p, b.offset)
+	b.offset += int64(n)
+
+	return n, err
+}
+
+func (b *RangeBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += b.offset
+	default:
+		return 0, errors.WithFields(
+			errors.New("invalid whence"),
+			errors.Fields{
+				"whence": whence,
+			})
+	}
+
+	if offset < 0 {
+		return 0, errors.WithFields(
+			errors.New("negative offset"),
+			errors.Fields{
+				"offset": offset,
+			})
+	}
+
+	b.offset = offset
+
+	return b.offset, nil
+}
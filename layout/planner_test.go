@@ -0,0 +1,127 @@
+package layout
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRanges(t *testing.T) {
+	t.Run("MergeCloseRanges", TestPlanRanges_MergeCloseRanges)
+	t.Run("KeepDistantRanges", TestPlanRanges_KeepDistantRanges)
+	t.Run("SplitLargeRanges", TestPlanRanges_SplitLargeRanges)
+	t.Run("MaxRangeSizePreventsMerge", TestPlanRanges_MaxRangeSizePreventsMerge)
+}
+
+func TestPlanRanges_MergeCloseRanges(t *testing.T) {
+	t.Parallel()
+
+	ranges := []ByteRange{
+		{Offset: 120, Length: 10},
+		{Offset: 100, Length: 10},
+		{Offset: 110, Length: 5},
+	}
+
+	res := PlanRanges(ranges, PlannerOptions{MaxGap: 5})
+
+	assert.Equal(t, []ByteRange{{Offset: 100, Length: 30}}, res)
+}
+
+func TestPlanRanges_KeepDistantRanges(t *testing.T) {
+	t.Parallel()
+
+	ranges := []ByteRange{
+		{Offset: 100, Length: 10},
+		{Offset: 200, Length: 10},
+	}
+
+	res := PlanRanges(ranges, PlannerOptions{MaxGap: 50})
+
+	assert.Equal(t, ranges, res)
+}
+
+func TestPlanRanges_SplitLargeRanges(t *testing.T) {
+	t.Parallel()
+
+	ranges := []ByteRange{
+		{Offset: 0, Length: 25},
+	}
+
+	res := PlanRanges(ranges, PlannerOptions{MaxRangeSize: 10})
+
+	assert.Equal(t, []ByteRange{
+		{Offset: 0, Length: 10},
+		{Offset: 10, Length: 10},
+		{Offset: 20, Length: 5},
+	}, res)
+}
+
+func TestPlanRanges_MaxRangeSizePreventsMerge(t *testing.T) {
+	t.Parallel()
+
+	ranges := []ByteRange{
+		{Offset: 0, Length: 8},
+		{Offset: 10, Length: 8},
+	}
+
+	res := PlanRanges(ranges, PlannerOptions{MaxGap: 5, MaxRangeSize: 10})
+
+	assert.Equal(t, ranges, res)
+}
+
+func TestRangeBuffer(t *testing.T) {
+	t.Run("Read", TestRangeBuffer_Read)
+	t.Run("Read_AcrossAdjacentRanges", TestRangeBuffer_Read_AcrossAdjacentRanges)
+	t.Run("Read_NotPrefetched", TestRangeBuffer_Read_NotPrefetched)
+}
+
+func TestRangeBuffer_Read(t *testing.T) {
+	t.Parallel()
+
+	src := memory.NewReader([]byte("0123456789abcdefghij"))
+
+	buf, err := FetchRanges(context.Background(), src, []ByteRange{{Offset: 2, Length: 4}, {Offset: 10, Length: 6}}, 0)
+	require.NoError(t, err)
+
+	_, err = buf.Seek(11, io.SeekStart)
+	require.NoError(t, err)
+
+	p := make([]byte, 3)
+	n, err := buf.Read(p)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "bcd", string(p))
+}
+
+func TestRangeBuffer_Read_AcrossAdjacentRanges(t *testing.T) {
+	t.Parallel()
+
+	src := memory.NewReader([]byte("0123456789abcdefghij"))
+
+	buf, err := FetchRanges(context.Background(), src, []ByteRange{{Offset: 0, Length: 5}, {Offset: 5, Length: 5}}, 1)
+	require.NoError(t, err)
+
+	p := make([]byte, 8)
+	n, err := buf.ReadAt(p, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, n)
+	assert.Equal(t, "12345678", string(p))
+}
+
+func TestRangeBuffer_Read_NotPrefetched(t *testing.T) {
+	t.Parallel()
+
+	src := memory.NewReader([]byte("0123456789abcdefghij"))
+
+	buf, err := FetchRanges(context.Background(), src, []ByteRange{{Offset: 0, Length: 5}}, 0)
+	require.NoError(t, err)
+
+	_, err = buf.ReadAt(make([]byte, 2), 4)
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package layout
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"math/bits"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/compression"
+	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// ScannedPage is a page found by ScanPages.
+type ScannedPage struct {
+	// Offset is the offset of the page header in the file.
+	Offset int64
+	// HeaderSize is the size of the encoded page header.
+	HeaderSize int64
+	// Header is the page header.
+	Header *parquet.PageHeader
+}
+
+// End returns the offset of the end of the page data.
+func (p ScannedPage) End() int64 {
+	return p.Offset + p.HeaderSize + int64(p.Header.CompressedPageSize)
+}
+
+// IsDictionary tells if the page is a dictionary page.
+func (p ScannedPage) IsDictionary() bool {
+	return p.Header.Type == parquet.PageType_DICTIONARY_PAGE
+}
+
+// ScanPages reads the consecutive pages of src, from offset up to end. It stops at the
+// first header that can't be read or isn't a valid data or dictionary page header,
+// and at the first page that doesn't fit before end.
+// Only the errors seeking src are returned.
+func ScanPages(ctx context.Context, src io.ReadSeeker, offset, end int64) ([]ScannedPage, error) {
+	var pages []ScannedPage
+
+	for offset < end {
+		if _, err := src.Seek(offset, io.SeekStart); err != nil {
+			return nil, errors.WithFields(
+				errors.Wrap(err, "failed to set the read index to the page start"),
+				errors.Fields{
+					"offset": offset,
+				})
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		reader := &offsetReader{ctx: ctx, inner: src, offset: offset}
+		header := &parquet.PageHeader{}
+
+		if err := readThrift(header, io.LimitReader(reader, end-offset)); err != nil {
+			break
+		}
+
+		p := ScannedPage{
+			Offset:     offset,
+			HeaderSize: reader.Count(),
+			Header:     header,
+		}
+
+		if !validScannedHeader(header) || p.End() > end {
+			break
+		}
+
+		pages = append(pages, p)
+		offset = p.End()
+	}
+
+	return pages, nil
+}
+
+func validScannedHeader(h *parquet.PageHeader) bool {
+	if h.CompressedPageSize < 0 || h.UncompressedPageSize < 0 {
+		return false
+	}
+
+	switch h.Type { //nolint:exhaustive // the other pages are not scanned
+	case parquet.PageType_DATA_PAGE:
+		return h.DataPageHeader != nil && h.DataPageHeader.NumValues >= 0
+	case parquet.PageType_DATA_PAGE_V2:
+		return h.DataPageHeaderV2 != nil &&
+			h.DataPageHeaderV2.NumValues >= 0 &&
+			h.DataPageHeaderV2.NumRows >= 0 &&
+			h.DataPageHeaderV2.RepetitionLevelsByteLength >= 0 &&
+			h.DataPageHeaderV2.DefinitionLevelsByteLength >= 0 &&
+			h.DataPageHeaderV2.RepetitionLevelsByteLength+h.DataPageHeaderV2.DefinitionLevelsByteLength <= h.CompressedPageSize
+	case parquet.PageType_DICTIONARY_PAGE:
+		return h.DictionaryPageHeader != nil && h.DictionaryPageHeader.NumValues >= 0
+	default:
+		return false
+	}
+}
+
+// ReadScannedPage returns the compressed data of p.
+// For DATA_PAGE_V2 pages, the levels, which are never compressed, are excluded.
+func ReadScannedPage(src io.ReaderAt, p ScannedPage) ([]byte, error) {
+	offset := p.Offset + p.HeaderSize
+	size := int64(p.Header.CompressedPageSize)
+
+	if h := p.Header.DataPageHeaderV2; h != nil {
+		levels := int64(h.RepetitionLevelsByteLength + h.DefinitionLevelsByteLength)
+		offset += levels
+		size -= levels
+	}
+
+	buf := make([]byte, size)
+	if _, err := src.ReadAt(buf, offset); err != nil && !(err == io.EOF && len(buf) == 0) {
+		return nil, errors.Wrap(err, "failed to read page data")
+	}
+
+	return buf, nil
+}
+
+// DetectCodec returns the compression codec of the page data read by ReadScannedPage,
+// among the provided compressors: the first one decompressing data into the uncompressed
+// size of the page. The codecs are tried in the order provided.
+func DetectCodec(p ScannedPage, data []byte, codecs []parquet.CompressionCodec, compressors map[parquet.CompressionCodec]compression.BlockCompressor) (parquet.CompressionCodec, error) {
+	size := int(p.Header.UncompressedPageSize)
+
+	if h := p.Header.DataPageHeaderV2; h != nil {
+		size -= int(h.RepetitionLevelsByteLength + h.DefinitionLevelsByteLength)
+	}
+
+	for _, codec := range codecs {
+		c, ok := compressors[codec]
+		if !ok {
+			continue
+		}
+
+		if res, err := c.DecompressBlock(data); err == nil && len(res) == size {
+			return codec, nil
+		}
+	}
+
+	return 0, errs.WithOffset(errs.New(errs.ErrUnsupportedCodec, "no codec decompresses the page"), p.Offset)
+}
+
+// PageRows returns the number of rows of the data page p, of a column with the given
+// maximum repetition level. The page data is only needed for the DATA_PAGE pages of
+// repeated columns: it is decompressed with the provided compressor, and the
+// repetition levels equal to 0, which start a new row, are counted.
+func PageRows(p ScannedPage, maxR uint16, data []byte, compressor compression.BlockCompressor) (int64, error) {
+	switch {
+	case p.Header.DataPageHeaderV2 != nil:
+		return int64(p.Header.DataPageHeaderV2.NumRows), nil
+	case p.Header.DataPageHeader == nil:
+		return 0, nil
+	case maxR == 0:
+		return int64(p.Header.DataPageHeader.NumValues), nil
+	}
+
+	raw, err := compressor.DecompressBlock(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var size uint32
+	if err := binary.Read(bytes.NewReader(raw), binary.LittleEndian, &size); err != nil {
+		return 0, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to read the repetition levels size"))
+	}
+
+	const sizeLen = 4
+
+	if int(size) > len(raw)-sizeLen {
+		return 0, errs.New(errs.ErrCorruptPage, "invalid repetition levels size")
+	}
+
+	dec := encoding.NewHybridDecoder(bits.Len16(maxR), true)
+	if err := dec.Init(bytes.NewReader(raw[sizeLen : sizeLen+int(size)])); err != nil {
+		return 0, errs.Wrap(errs.ErrCorruptPage, err)
+	}
+
+	var rows int64
+
+	for i := int32(0); i < p.Header.DataPageHeader.NumValues; i++ {
+		r, err := dec.Next()
+		if err != nil {
+			return 0, errs.Wrap(errs.ErrCorruptPage, errors.Wrap(err, "failed to read the repetition levels"))
+		}
+
+		if r == 0 {
+			rows++
+		}
+	}
+
+	return rows, nil
+}
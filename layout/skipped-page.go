@@ -0,0 +1,82 @@
+package layout
+
+import (
+	"context"
+	"io"
+	"math/bits"
+
+	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// PageErrorHandler decides whether a data page that failed to be read is replaced
+// by a SkippedPage (true) or makes the whole chunk fail (false).
+type PageErrorHandler func(err error) bool
+
+// SkippedPage stands for a data page that could not be read. It reads as many null
+// values as the page header declares, so the other pages of the chunk stay aligned.
+// Pages are only skipped in non-repeated columns, where a value is a row.
+type SkippedPage struct {
+	// Ordinal is the position of the page in the column chunk, the dictionary page included.
+	Ordinal int
+	// Offset is the offset of the page header in the file.
+	Offset int64
+	// Header is the header of the page.
+	Header *parquet.PageHeader
+	// Err is the error that made the page unreadable.
+	Err error
+
+	maxD     uint16
+	position int
+}
+
+func (p *SkippedPage) init(getLevelDecoderFn, getLevelDecoderFn, getValueDecoderFn, compressorMap) error {
+	return nil
+}
+
+func (p *SkippedPage) read(context.Context, io.Reader, *parquet.PageHeader, parquet.CompressionCodec) error {
+	return nil
+}
+
+// ReadValues reads null values: the definition levels are all 0 and values are left nil.
+func (p *SkippedPage) ReadValues(values []interface{}) (n int, dLevel *encoding.PackedArray, rLevel *encoding.PackedArray, err error) {
+	size := len(values)
+	if rem := int(p.NumValues()) - p.position; rem < size {
+		size = rem
+	}
+
+	dLevel = &encoding.PackedArray{}
+	if err := dLevel.Reset(bits.Len16(p.maxD)); err != nil {
+		return 0, nil, nil, err
+	}
+
+	rLevel = &encoding.PackedArray{}
+	if err := rLevel.Reset(0); err != nil {
+		return 0, nil, nil, err
+	}
+
+	for i := 0; i < size; i++ {
+		values[i] = nil
+
+		dLevel.AppendSingle(0)
+		rLevel.AppendSingle(0)
+	}
+
+	p.position += size
+
+	return size, dLevel, rLevel, nil
+}
+
+// Location returns the ordinal of the page in its column chunk and the offset of its header.
+func (p *SkippedPage) Location() (ordinal int, offset int64) {
+	return p.Ordinal, p.Offset
+}
+
+func (p *SkippedPage) setLocation(ordinal int, offset int64) {
+	p.Ordinal, p.Offset = ordinal, offset
+}
+
+// NumValues returns the number of values declared by the page header.
+func (p *SkippedPage) NumValues() int32 {
+	return PageNumValues(p.Header)
+}
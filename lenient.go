@@ -0,0 +1,219 @@
+package parquet
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/layout"
+	"github.com/hexbee-net/parquet/schema"
+)
+
+// SkipLevel is the unit of data skipped by a lenient FileReader.
+type SkipLevel int
+
+const (
+	// SkipPage skips the corrupt data pages. The pages of repeated columns, and the pages
+	// with a corrupt header, can't be skipped on their own: their column chunk is skipped.
+	SkipPage SkipLevel = iota + 1
+	// SkipChunk skips the column chunks with a corrupt page.
+	SkipChunk
+	// SkipRowGroup skips the row groups with a corrupt page or column chunk.
+	SkipRowGroup
+)
+
+func (l SkipLevel) String() string {
+	switch l {
+	case SkipPage:
+		return "page"
+	case SkipChunk:
+		return "chunk"
+	case SkipRowGroup:
+		return "row group"
+	default:
+		return "unknown"
+	}
+}
+
+// LenientOptions configures how a FileReader recovers from corrupt data.
+//
+// The data that can't be read is replaced by nulls, or its rows are dropped, and the
+// reading goes on. The errors that can be recovered from are the ones of the kinds
+// ErrCorruptPage, ErrCorruptFooter (column chunk meta-data only), ErrUnsupportedEncoding,
+// ErrUnsupportedCodec, ErrUnsupportedType and ErrSchemaMismatch. The errors of the source,
+// like I/O errors, are returned as they are and still stop the reading.
+type LenientOptions struct {
+	// Level is the smallest unit skipped. When a page or a column chunk can't be
+	// skipped on its own, the enclosing unit is skipped instead.
+	Level SkipLevel
+	// DropRows drops the rows of the skipped pages instead of reading nulls in their place.
+	// Since a column chunk holds all the rows of its row group, dropping the rows of a
+	// skipped column chunk skips the whole row group.
+	DropRows bool
+	// OnSkip, if not nil, is called for each skipped page, column chunk or row group.
+	OnSkip func(e SkipEvent)
+}
+
+// SkipEvent describes data skipped by a lenient FileReader.
+type SkipEvent struct {
+	// Level is the unit that was skipped.
+	Level SkipLevel
+	// RowGroup is the index of the row group.
+	RowGroup int
+	// Column is the path of the column, nil when a row group is skipped.
+	Column []string
+	// Page is the ordinal of the page in the column chunk, the dictionary page included,
+	// or -1 when a column chunk or a row group is skipped.
+	Page int
+	// Offset is the offset of the corrupt data in the file, -1 if unknown.
+	Offset int64
+	// FirstRow is the index of the first row affected, in the row group.
+	FirstRow int64
+	// NumRows is the number of rows affected.
+	NumRows int64
+	// Dropped is true when the rows were dropped, false when nulls were read in their place.
+	Dropped bool
+	// Err is the error that made the data unreadable.
+	Err error
+}
+
+type rowRange struct {
+	first, end int64
+}
+
+// SetLenient makes the reader skip the corrupt data as described by opts,
+// nil restores the default behaviour of failing on the first error.
+func (f *FileReader) SetLenient(opts *LenientOptions) {
+	f.lenient = opts
+
+	if opts == nil || opts.Level != SkipPage {
+		f.chunkReader.SetPageErrorHandler(nil)
+		return
+	}
+
+	f.chunkReader.SetPageErrorHandler(isRecoverable)
+}
+
+// isRecoverable tells if err is caused by data that can be skipped.
+// The errors of the source have none of these kinds.
+func isRecoverable(err error) bool {
+	return stderrors.Is(err, ErrCorruptPage) ||
+		stderrors.Is(err, ErrCorruptFooter) ||
+		stderrors.Is(err, ErrUnsupportedEncoding) ||
+		stderrors.Is(err, ErrUnsupportedCodec) ||
+		stderrors.Is(err, ErrUnsupportedType) ||
+		stderrors.Is(err, ErrSchemaMismatch)
+}
+
+// canSkip tells if the unit at the given level can be skipped because of err.
+func (f *FileReader) canSkip(level SkipLevel, err error) bool {
+	return f.lenient != nil && f.lenient.Level <= level && isRecoverable(err)
+}
+
+// skipPage reads nulls in place of a page of the flat column col, and drops its rows if required.
+func (f *FileReader) skipPage(col *schema.Column, p layout.PageReader, err error) {
+	s := col.ColumnStore()
+	first := int64(s.DefinitionLevels.Count())
+	n := int64(p.NumValues())
+
+	for i := int64(0); i < n; i++ {
+		s.DefinitionLevels.AppendSingle(0)
+		s.RepetitionLevels.AppendSingle(0)
+
+		// A null of a required column is a nil value.
+		if col.MaxDefinitionLevel() == 0 {
+			s.Values.Values = append(s.Values.Values, nil)
+		}
+	}
+
+	s.Values.NoDictMode = true
+
+	if f.lenient.DropRows {
+		f.dropped = append(f.dropped, rowRange{first: first, end: first + n})
+	}
+
+	ordinal, offset := p.Location()
+
+	f.reportSkip(SkipEvent{
+		Level:    SkipPage,
+		RowGroup: f.rowGroupPosition - 1,
+		Column:   col.Path(),
+		Page:     ordinal,
+		Offset:   offset,
+		FirstRow: first,
+		NumRows:  n,
+		Dropped:  f.lenient.DropRows,
+		Err:      err,
+	})
+}
+
+// skipChunk reads nulls in place of the column chunk of col. If the rows have to be dropped,
+// err is returned to skip the row group.
+func (f *FileReader) skipChunk(col *schema.Column, err error) error {
+	if f.lenient.DropRows || !f.canSkip(SkipChunk, err) {
+		return err
+	}
+
+	col.SetSkipped(true)
+
+	f.reportSkip(SkipEvent{
+		Level:    SkipChunk,
+		RowGroup: f.rowGroupPosition - 1,
+		Column:   col.Path(),
+		Page:     errs.Unknown,
+		Offset:   errorOffset(err),
+		NumRows:  f.Reader.RowGroupNumRecords(),
+		Err:      err,
+	})
+
+	return nil
+}
+
+// dropRowGroup reports the row group that failed to be loaded, if it can be skipped.
+func (f *FileReader) dropRowGroup(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || !f.canSkip(SkipRowGroup, err) {
+		return false
+	}
+
+	index := f.rowGroupPosition - 1
+
+	f.reportSkip(SkipEvent{
+		Level:    SkipRowGroup,
+		RowGroup: index,
+		Page:     errs.Unknown,
+		Offset:   errorOffset(err),
+		NumRows:  f.meta.RowGroups[index].NumRows,
+		Dropped:  true,
+		Err:      err,
+	})
+
+	return true
+}
+
+func (f *FileReader) reportSkip(e SkipEvent) {
+	e.Err = errs.WithRowGroup(e.Err, e.RowGroup)
+
+	if f.lenient.OnSkip != nil {
+		f.lenient.OnSkip(e)
+	}
+}
+
+// isDropped tells if the row of the current row group at the given index was dropped.
+func (f *FileReader) isDropped(row int64) bool {
+	for _, r := range f.dropped {
+		if row >= r.first && row < r.end {
+			return true
+		}
+	}
+
+	return false
+}
+
+func errorOffset(err error) int64 {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e.Offset
+	}
+
+	return errs.Unknown
+}
@@ -0,0 +1,243 @@
+package parquet
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // Read-only test fixture.
+var lenientTestColumns = []testColumn{
+	{name: "id", factor: 1},
+	{name: "a", optional: true, factor: 2},
+}
+
+func readAllRows(t *testing.T, data []byte, opts *LenientOptions) ([]map[string]interface{}, error) {
+	t.Helper()
+
+	r, err := NewFileReader(memory.NewReader(data))
+	require.NoError(t, err)
+
+	r.SetLenient(opts)
+
+	return readRows(r)
+}
+
+func TestFileReader_Lenient(t *testing.T) {
+	t.Run("Valid", TestFileReader_Lenient_Valid)
+	t.Run("Strict", TestFileReader_Lenient_Strict)
+	t.Run("SkipPage", TestFileReader_Lenient_SkipPage)
+	t.Run("SkipPageDropRows", TestFileReader_Lenient_SkipPageDropRows)
+	t.Run("SkipChunk", TestFileReader_Lenient_SkipChunk)
+	t.Run("SkipRowGroup", TestFileReader_Lenient_SkipRowGroup)
+	t.Run("CorruptPageHeader", TestFileReader_Lenient_CorruptPageHeader)
+	t.Run("ReadError", TestFileReader_Lenient_ReadError)
+}
+
+func TestFileReader_Lenient_Valid(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+
+	rows, err := readAllRows(t, file.data, nil)
+	require.NoError(t, err)
+	require.Len(t, rows, 60)
+
+	for i, row := range rows {
+		assert.Equal(t, int64(i), row["id"])
+
+		if i%3 == 0 {
+			assert.NotContains(t, row, "a")
+		} else {
+			assert.Equal(t, int64(i*2), row["a"])
+		}
+	}
+}
+
+func TestFileReader_Lenient_Strict(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	file.corrupt(1, 0, 2)
+
+	rows, err := readAllRows(t, file.data, nil)
+	require.Error(t, err)
+	assert.Len(t, rows, 30)
+	assert.True(t, stderrors.Is(err, ErrCorruptPage))
+
+	var e *Error
+
+	require.True(t, stderrors.As(err, &e))
+	assert.Equal(t, 1, e.RowGroup)
+	assert.Equal(t, []string{"id"}, e.Column)
+	assert.Equal(t, 2, e.Page)
+	assert.NotEqual(t, int64(-1), e.Offset)
+}
+
+func TestFileReader_Lenient_SkipPage(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	file.corrupt(1, 1, 1)
+
+	var events []SkipEvent
+
+	rows, err := readAllRows(t, file.data, &LenientOptions{
+		Level:  SkipPage,
+		OnSkip: func(e SkipEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 60)
+
+	for i, row := range rows {
+		assert.Equal(t, int64(i), row["id"])
+
+		if i%3 == 0 || (i >= 40 && i < 50) {
+			assert.NotContains(t, row, "a")
+		} else {
+			assert.Equal(t, int64(i*2), row["a"])
+		}
+	}
+
+	require.Len(t, events, 1)
+	assert.Equal(t, SkipPage, events[0].Level)
+	assert.Equal(t, 1, events[0].RowGroup)
+	assert.Equal(t, []string{"a"}, events[0].Column)
+	assert.Equal(t, 1, events[0].Page)
+	assert.Equal(t, int64(10), events[0].FirstRow)
+	assert.Equal(t, int64(10), events[0].NumRows)
+	assert.False(t, events[0].Dropped)
+	assert.True(t, stderrors.Is(events[0].Err, ErrCorruptPage))
+}
+
+func TestFileReader_Lenient_SkipPageDropRows(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	file.corrupt(0, 0, 0)
+
+	var events []SkipEvent
+
+	rows, err := readAllRows(t, file.data, &LenientOptions{
+		Level:    SkipPage,
+		DropRows: true,
+		OnSkip:   func(e SkipEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 50)
+	assert.Equal(t, int64(10), rows[0]["id"])
+
+	require.Len(t, events, 1)
+	assert.Equal(t, SkipPage, events[0].Level)
+	assert.True(t, events[0].Dropped)
+}
+
+func TestFileReader_Lenient_SkipChunk(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	file.corrupt(0, 1, 2)
+
+	var events []SkipEvent
+
+	rows, err := readAllRows(t, file.data, &LenientOptions{
+		Level:  SkipChunk,
+		OnSkip: func(e SkipEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 60)
+
+	for i, row := range rows {
+		assert.Equal(t, int64(i), row["id"])
+
+		if i < 30 {
+			assert.NotContains(t, row, "a")
+		}
+	}
+
+	require.Len(t, events, 1)
+	assert.Equal(t, SkipChunk, events[0].Level)
+	assert.Equal(t, 0, events[0].RowGroup)
+	assert.Equal(t, []string{"a"}, events[0].Column)
+	assert.Equal(t, int64(30), events[0].NumRows)
+}
+
+func TestFileReader_Lenient_SkipRowGroup(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 3, 30, 3)
+	file.corrupt(1, 0, 1)
+
+	var events []SkipEvent
+
+	rows, err := readAllRows(t, file.data, &LenientOptions{
+		Level:  SkipRowGroup,
+		OnSkip: func(e SkipEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 60)
+	assert.Equal(t, int64(29), rows[29]["id"])
+	assert.Equal(t, int64(60), rows[30]["id"])
+
+	require.Len(t, events, 1)
+	assert.Equal(t, SkipRowGroup, events[0].Level)
+	assert.Equal(t, 1, events[0].RowGroup)
+	assert.Nil(t, events[0].Column)
+	assert.True(t, events[0].Dropped)
+}
+
+func TestFileReader_Lenient_CorruptPageHeader(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+
+	// The header of the second page, before its data, can't be skipped on its own.
+	start := file.pages[0][1][0][1]
+	for i := start; i < file.pages[0][1][1][0]; i++ {
+		file.data[i] = 0xff
+	}
+
+	var events []SkipEvent
+
+	rows, err := readAllRows(t, file.data, &LenientOptions{
+		Level:  SkipPage,
+		OnSkip: func(e SkipEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 60)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, SkipChunk, events[0].Level)
+	assert.Equal(t, []string{"a"}, events[0].Column)
+}
+
+func TestFileReader_Lenient_ReadError(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+
+	// The source fails from the second page of the column a in the second row group.
+	r, err := NewFileReader(&failingReaderAt{
+		Reader: memory.NewReader(file.data),
+		offset: int64(file.pages[1][1][1][0]),
+	})
+	require.NoError(t, err)
+
+	var events []SkipEvent
+
+	r.SetLenient(&LenientOptions{
+		Level:  SkipPage,
+		OnSkip: func(e SkipEvent) { events = append(events, e) },
+	})
+
+	// The failure of the source isn't skipped, whatever the level.
+	rows, err := readRows(r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read failure")
+	assert.False(t, stderrors.Is(err, ErrCorruptPage))
+	assert.Len(t, rows, 30)
+	assert.Empty(t, events)
+}
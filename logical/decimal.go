@@ -0,0 +1,309 @@
+package logical
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// MaxInt32Precision and MaxInt64Precision are the maximum precisions of the decimals stored in INT32 and INT64.
+const (
+	MaxInt32Precision = 9
+	MaxInt64Precision = 18
+)
+
+const (
+	errInvalidDecimal = errors.Error("invalid decimal")
+
+	bitsPerByte = 8
+)
+
+// Decimal is the value of a DECIMAL column: Unscaled * 10^-Scale.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int32
+}
+
+// NewDecimal creates a decimal from its unscaled value and its scale.
+func NewDecimal(unscaled *big.Int, scale int32) Decimal {
+	return Decimal{Unscaled: unscaled, Scale: scale}
+}
+
+// NewDecimalFromInt64 creates a decimal from its unscaled value and its scale.
+func NewDecimalFromInt64(unscaled int64, scale int32) Decimal {
+	return Decimal{Unscaled: big.NewInt(unscaled), Scale: scale}
+}
+
+// ParseDecimal parses the decimal representation of a number, like "-12.340", its
+// scale is the number of digits after the decimal point.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := s
+	scale := 0
+
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = len(s) - i - 1
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, errors.WithFields(
+			errors.WithStack(errInvalidDecimal),
+			errors.Fields{
+				"value": s,
+			})
+	}
+
+	return Decimal{Unscaled: unscaled, Scale: int32(scale)}, nil
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.Unscaled
+}
+
+// String returns the decimal representation of d, with Scale digits after the decimal point.
+func (d Decimal) String() string {
+	u := d.unscaled()
+	if d.Scale <= 0 {
+		return new(big.Int).Mul(u, pow10(-d.Scale)).String()
+	}
+
+	digits := new(big.Int).Abs(u).String()
+	if pad := int(d.Scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	sign := ""
+	if u.Sign() < 0 {
+		sign = "-"
+	}
+
+	point := len(digits) - int(d.Scale)
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Rat returns d as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	if d.Scale <= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(d.unscaled(), pow10(-d.Scale)))
+	}
+
+	return new(big.Rat).SetFrac(d.unscaled(), pow10(d.Scale))
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+
+	return f
+}
+
+// Cmp compares d and e, and returns -1, 0 or +1 if d is less than, equal to, or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.Rat().Cmp(e.Rat())
+}
+
+// Precision returns the number of digits of the unscaled value of d.
+func (d Decimal) Precision() int32 {
+	return int32(len(new(big.Int).Abs(d.unscaled()).String()))
+}
+
+// Rescale returns d with the given scale. It fails if digits would be lost.
+func (d Decimal) Rescale(scale int32) (Decimal, error) {
+	switch {
+	case scale == d.Scale:
+		return d, nil
+	case scale > d.Scale:
+		return Decimal{Unscaled: new(big.Int).Mul(d.unscaled(), pow10(scale-d.Scale)), Scale: scale}, nil
+	}
+
+	q, r := new(big.Int).QuoRem(d.unscaled(), pow10(d.Scale-scale), new(big.Int))
+	if r.Sign() != 0 {
+		return Decimal{}, errors.WithFields(
+			errors.New("decimal can't be rescaled without losing digits"),
+			errors.Fields{
+				"value": d.String(),
+				"scale": scale,
+			})
+	}
+
+	return Decimal{Unscaled: q, Scale: scale}, nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// decimalParams returns the precision and the scale of a DECIMAL column.
+// The logical type takes precedence over the converted type.
+func decimalParams(elem *parquet.SchemaElement) (precision, scale int32, ok bool) {
+	if lt := elem.GetLogicalType(); lt != nil && lt.IsSetDECIMAL() {
+		return lt.DECIMAL.Precision, lt.DECIMAL.Scale, true
+	}
+
+	if elem.IsSetConvertedType() && elem.GetConvertedType() == parquet.ConvertedType_DECIMAL {
+		return elem.GetPrecision(), elem.GetScale(), true
+	}
+
+	return 0, 0, false
+}
+
+// IsDecimal tells if elem describes a DECIMAL column.
+func IsDecimal(elem *parquet.SchemaElement) bool {
+	_, _, ok := decimalParams(elem)
+
+	return ok
+}
+
+// maxPrecision returns the maximum precision of the decimals stored with the physical
+// type of elem, 0 if the type can't store decimals.
+func maxPrecision(elem *parquet.SchemaElement) int32 {
+	switch elem.GetType() { //nolint:exhaustive // the other types can't store decimals
+	case parquet.Type_INT32:
+		return MaxInt32Precision
+	case parquet.Type_INT64:
+		return MaxInt64Precision
+	case parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		// floor(log10(2^(8*n-1) - 1))
+		return int32(math.Floor(float64(bitsPerByte*elem.GetTypeLength()-1) * math.Log10(2)))
+	case parquet.Type_BYTE_ARRAY:
+		return math.MaxInt32
+	default:
+		return 0
+	}
+}
+
+// ValidateDecimal checks the precision and the scale of a DECIMAL column against its
+// physical type. It returns nil for the other columns.
+func ValidateDecimal(elem *parquet.SchemaElement) error {
+	precision, scale, ok := decimalParams(elem)
+	if !ok {
+		return nil
+	}
+
+	fields := errors.Fields{
+		"column":    elem.GetName(),
+		"precision": precision,
+		"scale":     scale,
+	}
+
+	if lt := elem.GetLogicalType(); lt != nil && lt.IsSetDECIMAL() &&
+		(elem.IsSetPrecision() && elem.GetPrecision() != precision || elem.IsSetScale() && elem.GetScale() != scale) {
+		return errors.WithFields(errors.New("decimal logical type doesn't match the precision and scale of the column"), fields)
+	}
+
+	maxP := maxPrecision(elem)
+
+	switch {
+	case maxP == 0:
+		return errors.WithFields(errors.New("decimal can't be stored in the column physical type"), fields)
+	case precision <= 0:
+		return errors.WithFields(errors.New("decimal precision must be positive"), fields)
+	case precision > maxP:
+		fields["max-precision"] = maxP
+		return errors.WithFields(errors.New("decimal precision too large for the column physical type"), fields)
+	case scale < 0 || scale > precision:
+		return errors.WithFields(errors.New("decimal scale must be between 0 and the precision"), fields)
+	}
+
+	return nil
+}
+
+// decimalToLogical converts an unscaled INT32, INT64 or big-endian two's complement value to a Decimal.
+func decimalToLogical(v interface{}, scale int32) (interface{}, error) {
+	switch n := v.(type) {
+	case int32:
+		return NewDecimalFromInt64(int64(n), scale), nil
+	case int64:
+		return NewDecimalFromInt64(n, scale), nil
+	case []byte:
+		return Decimal{Unscaled: fromTwosComplement(n), Scale: scale}, nil
+	default:
+		return nil, unexpectedType(v, "int32, int64 or []byte")
+	}
+}
+
+// decimalToPhysical converts a Decimal to the physical type of elem, after checking its precision.
+func decimalToPhysical(elem *parquet.SchemaElement, v interface{}, precision, scale int32) (interface{}, error) {
+	d, ok := v.(Decimal)
+	if !ok {
+		switch v.(type) {
+		case int32, int64, []byte:
+			return v, nil
+		default:
+			return nil, unexpectedType(v, "logical.Decimal")
+		}
+	}
+
+	d, err := d.Rescale(scale)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Precision() > precision {
+		return nil, errors.WithFields(
+			errors.WithStack(errInvalidDecimal),
+			errors.Fields{
+				"value":     d.String(),
+				"precision": precision,
+			})
+	}
+
+	u := d.unscaled()
+
+	switch elem.GetType() { //nolint:exhaustive // the other types can't store decimals
+	case parquet.Type_INT32:
+		return int32(u.Int64()), nil
+	case parquet.Type_INT64:
+		return u.Int64(), nil
+	case parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		return toTwosComplement(u, int(elem.GetTypeLength())), nil
+	default:
+		return toTwosComplement(u, 0), nil
+	}
+}
+
+// fromTwosComplement decodes a big-endian two's complement integer.
+func fromTwosComplement(b []byte) *big.Int {
+	n := new(big.Int).SetBytes(b)
+
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*bitsPerByte)))
+	}
+
+	return n
+}
+
+// toTwosComplement encodes n as a big-endian two's complement integer of size bytes,
+// or of the minimal size when size is 0.
+func toTwosComplement(n *big.Int, size int) []byte {
+	if size == 0 {
+		// The sign bit needs one more bit than the magnitude, except for -2^(8k-1).
+		bitLen := n.BitLen()
+		if n.Sign() < 0 {
+			bitLen = new(big.Int).Add(n, big.NewInt(1)).BitLen()
+		}
+
+		size = bitLen/bitsPerByte + 1
+	}
+
+	v := n
+	if n.Sign() < 0 {
+		v = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), uint(size*bitsPerByte)))
+	}
+
+	b := v.Bytes()
+	if len(b) >= size {
+		return b[len(b)-size:]
+	}
+
+	return append(make([]byte, size-len(b)), b...)
+}
@@ -0,0 +1,185 @@
+package logical
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decimalElement(typ parquet.Type, length, precision, scale int32) *parquet.SchemaElement {
+	elem := element(typ, &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}, nil)
+	if length > 0 {
+		elem.TypeLength = &length
+	}
+
+	return elem
+}
+
+func TestDecimal(t *testing.T) {
+	t.Run("String", TestDecimal_String)
+	t.Run("Parse", TestDecimal_Parse)
+	t.Run("Conversions", TestDecimal_Conversions)
+	t.Run("Rescale", TestDecimal_Rescale)
+	t.Run("Physical", TestDecimal_Physical)
+	t.Run("ConvertedType", TestDecimal_ConvertedType)
+	t.Run("Precision", TestDecimal_Precision)
+	t.Run("Validate", TestDecimal_Validate)
+}
+
+func TestDecimal_String(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		unscaled int64
+		scale    int32
+		expected string
+	}{
+		{unscaled: 12345, scale: 2, expected: "123.45"},
+		{unscaled: -12345, scale: 2, expected: "-123.45"},
+		{unscaled: 5, scale: 3, expected: "0.005"},
+		{unscaled: -5, scale: 3, expected: "-0.005"},
+		{unscaled: 0, scale: 2, expected: "0.00"},
+		{unscaled: 42, scale: 0, expected: "42"},
+		{unscaled: 42, scale: -2, expected: "4200"},
+	} {
+		assert.Equal(t, tt.expected, NewDecimalFromInt64(tt.unscaled, tt.scale).String())
+	}
+
+	assert.Equal(t, "0.0", Decimal{Scale: 1}.String())
+}
+
+func TestDecimal_Parse(t *testing.T) {
+	t.Parallel()
+
+	d, err := ParseDecimal("-12.340")
+	require.NoError(t, err)
+	assert.Equal(t, NewDecimalFromInt64(-12340, 3), d)
+
+	d, err = ParseDecimal("7")
+	require.NoError(t, err)
+	assert.Equal(t, NewDecimalFromInt64(7, 0), d)
+
+	for _, s := range []string{"", "1.2.3", "abc", "1e5"} {
+		_, err := ParseDecimal(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestDecimal_Conversions(t *testing.T) {
+	t.Parallel()
+
+	d := NewDecimalFromInt64(-12345, 2)
+
+	assert.Equal(t, big.NewRat(-12345, 100), d.Rat())
+	assert.InDelta(t, -123.45, d.Float64(), 1e-9)
+	assert.Equal(t, 0, d.Cmp(NewDecimalFromInt64(-123450, 3)))
+	assert.Equal(t, -1, d.Cmp(NewDecimalFromInt64(1, 0)))
+}
+
+func TestDecimal_Rescale(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewDecimalFromInt64(12345, 2).Rescale(4)
+	require.NoError(t, err)
+	assert.Equal(t, NewDecimalFromInt64(1234500, 4), d)
+
+	d, err = NewDecimalFromInt64(12300, 3).Rescale(1)
+	require.NoError(t, err)
+	assert.Equal(t, NewDecimalFromInt64(123, 1), d)
+
+	_, err = NewDecimalFromInt64(12345, 2).Rescale(1)
+	assert.Error(t, err)
+}
+
+func TestDecimal_Physical(t *testing.T) {
+	t.Parallel()
+
+	d := NewDecimalFromInt64(-12345, 2)
+
+	roundTrip(t, decimalElement(parquet.Type_INT32, 0, 9, 2), nil, int32(-12345), d)
+	roundTrip(t, decimalElement(parquet.Type_INT64, 0, 18, 2), nil, int64(-12345), d)
+	roundTrip(t, decimalElement(parquet.Type_FIXED_LEN_BYTE_ARRAY, 4, 9, 2), nil, []byte{0xff, 0xff, 0xcf, 0xc7}, d)
+	roundTrip(t, decimalElement(parquet.Type_BYTE_ARRAY, 0, 9, 2), nil, []byte{0xcf, 0xc7}, d)
+
+	// The minimal two's complement encodings.
+	for _, tt := range []struct {
+		unscaled int64
+		expected []byte
+	}{
+		{unscaled: 127, expected: []byte{0x7f}},
+		{unscaled: 128, expected: []byte{0x00, 0x80}},
+		{unscaled: -128, expected: []byte{0x80}},
+		{unscaled: -129, expected: []byte{0xff, 0x7f}},
+	} {
+		roundTrip(t, decimalElement(parquet.Type_BYTE_ARRAY, 0, 9, 0), nil, tt.expected, NewDecimalFromInt64(tt.unscaled, 0))
+	}
+
+	v, err := ToPhysical(decimalElement(parquet.Type_BYTE_ARRAY, 0, 9, 0), NewDecimalFromInt64(0, 0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00}, v)
+
+	// A value with a smaller scale is rescaled.
+	v, err = ToPhysical(decimalElement(parquet.Type_INT64, 0, 18, 4), NewDecimalFromInt64(15, 1), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(15000), v)
+}
+
+func TestDecimal_ConvertedType(t *testing.T) {
+	t.Parallel()
+
+	precision, scale := int32(5), int32(1)
+	elem := element(parquet.Type_INT32, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL))
+	elem.Precision = &precision
+	elem.Scale = &scale
+
+	assert.True(t, IsDecimal(elem))
+	roundTrip(t, elem, nil, int32(15), NewDecimalFromInt64(15, 1))
+}
+
+func TestDecimal_Precision(t *testing.T) {
+	t.Parallel()
+
+	elem := decimalElement(parquet.Type_INT64, 0, 4, 2)
+
+	_, err := ToPhysical(elem, NewDecimalFromInt64(12345, 2), nil)
+	assert.Error(t, err)
+
+	_, err = ToPhysical(elem, NewDecimalFromInt64(1, 3), nil)
+	assert.Error(t, err)
+
+	_, err = ToPhysical(elem, "1.5", nil)
+	assert.Error(t, err)
+}
+
+func TestDecimal_Validate(t *testing.T) {
+	t.Parallel()
+
+	for _, elem := range []*parquet.SchemaElement{
+		decimalElement(parquet.Type_INT32, 0, 9, 2),
+		decimalElement(parquet.Type_INT64, 0, 18, 18),
+		decimalElement(parquet.Type_FIXED_LEN_BYTE_ARRAY, 16, 38, 10),
+		decimalElement(parquet.Type_BYTE_ARRAY, 0, 100, 10),
+		element(parquet.Type_INT32, nil, nil),
+	} {
+		assert.NoError(t, ValidateDecimal(elem))
+	}
+
+	mismatch := decimalElement(parquet.Type_INT32, 0, 9, 2)
+	mismatch.Scale = new(int32)
+
+	for _, elem := range []*parquet.SchemaElement{
+		decimalElement(parquet.Type_INT32, 0, 10, 2),
+		decimalElement(parquet.Type_INT64, 0, 19, 2),
+		decimalElement(parquet.Type_FIXED_LEN_BYTE_ARRAY, 16, 39, 2),
+		decimalElement(parquet.Type_DOUBLE, 0, 5, 2),
+		decimalElement(parquet.Type_INT32, 0, 0, 0),
+		decimalElement(parquet.Type_INT32, 0, 5, 6),
+		decimalElement(parquet.Type_INT32, 0, 5, -1),
+		mismatch,
+	} {
+		assert.Error(t, ValidateDecimal(elem))
+	}
+}
@@ -0,0 +1,206 @@
+// Package logical converts the values of the columns annotated with a logical type
+// between their physical representation and a Go representation.
+package logical
+
+import (
+	"time"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+const (
+	errUnexpectedType = errors.Error("unexpected value type")
+	errInvalidValue   = errors.Error("invalid value")
+)
+
+// Options configures the conversions.
+type Options struct {
+	// Location is the time zone of the timestamps that are not adjusted to UTC.
+	// UTC is used when nil.
+	Location *time.Location
+}
+
+func (o *Options) location() *time.Location {
+	if o == nil || o.Location == nil {
+		return time.UTC
+	}
+
+	return o.Location
+}
+
+type kind int
+
+const (
+	kindNone kind = iota
+	kindDate
+	kindTimestamp
+	kindTime
+	kindInt96
+	kindInterval
+	kindDecimal
+)
+
+type unit int64
+
+const (
+	unitMillis unit = 1e6
+	unitMicros unit = 1e3
+	unitNanos  unit = 1
+)
+
+// conversion is the conversion of the values of a column.
+type conversion struct {
+	kind kind
+	unit unit
+	utc  bool
+
+	precision, scale int32
+}
+
+func newUnit(u *parquet.TimeUnit) unit {
+	switch {
+	case u.IsSetMICROS():
+		return unitMicros
+	case u.IsSetNANOS():
+		return unitNanos
+	default:
+		return unitMillis
+	}
+}
+
+// conversionOf returns the conversion of the values of the column described by elem.
+// The logical type takes precedence over the converted type.
+func conversionOf(elem *parquet.SchemaElement) conversion {
+	if elem == nil {
+		return conversion{}
+	}
+
+	if precision, scale, ok := decimalParams(elem); ok {
+		return conversion{kind: kindDecimal, precision: precision, scale: scale}
+	}
+
+	if lt := elem.GetLogicalType(); lt != nil {
+		switch {
+		case lt.IsSetDATE():
+			return conversion{kind: kindDate}
+		case lt.IsSetTIMESTAMP():
+			return conversion{kind: kindTimestamp, unit: newUnit(lt.TIMESTAMP.GetUnit()), utc: lt.TIMESTAMP.IsAdjustedToUTC}
+		case lt.IsSetTIME():
+			return conversion{kind: kindTime, unit: newUnit(lt.TIME.GetUnit())}
+		}
+	}
+
+	if elem.IsSetConvertedType() {
+		switch elem.GetConvertedType() { //nolint:exhaustive // only the temporal types are converted
+		case parquet.ConvertedType_DATE:
+			return conversion{kind: kindDate}
+		case parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return conversion{kind: kindTimestamp, unit: unitMillis, utc: true}
+		case parquet.ConvertedType_TIMESTAMP_MICROS:
+			return conversion{kind: kindTimestamp, unit: unitMicros, utc: true}
+		case parquet.ConvertedType_TIME_MILLIS:
+			return conversion{kind: kindTime, unit: unitMillis}
+		case parquet.ConvertedType_TIME_MICROS:
+			return conversion{kind: kindTime, unit: unitMicros}
+		case parquet.ConvertedType_INTERVAL:
+			return conversion{kind: kindInterval}
+		}
+	}
+
+	if elem.GetType() == parquet.Type_INT96 {
+		return conversion{kind: kindInt96}
+	}
+
+	return conversion{}
+}
+
+// HasConversion tells if the values of the column described by elem are converted by ToLogical and ToPhysical.
+func HasConversion(elem *parquet.SchemaElement) bool {
+	return conversionOf(elem).kind != kindNone
+}
+
+// ToLogical converts v, a value of the column described by elem as returned by the
+// reader, to its logical representation:
+//   - DATE to a time.Time at midnight UTC,
+//   - TIMESTAMP, TIMESTAMP_MILLIS and TIMESTAMP_MICROS to a time.Time, in UTC when
+//     adjusted to UTC, in the location of the options otherwise,
+//   - INT96 Julian day timestamps to a time.Time in UTC,
+//   - TIME, TIME_MILLIS and TIME_MICROS to a time.Duration since midnight,
+//   - INTERVAL to an Interval,
+//   - DECIMAL, stored in an INT32, an INT64, a FIXED_LEN_BYTE_ARRAY or a BYTE_ARRAY, to a Decimal.
+//
+// The values of the other columns, and nil, are returned unchanged.
+func ToLogical(elem *parquet.SchemaElement, v interface{}, opts *Options) (interface{}, error) {
+	c := conversionOf(elem)
+	if c.kind == kindNone || v == nil {
+		return v, nil
+	}
+
+	var (
+		res interface{}
+		err error
+	)
+
+	switch c.kind { //nolint:exhaustive // kindNone is handled above
+	case kindDate:
+		res, err = dateToTime(v)
+	case kindTimestamp:
+		res, err = timestampToTime(v, c.unit, c.utc, opts.location())
+	case kindTime:
+		res, err = timeToDuration(v, c.unit)
+	case kindInt96:
+		res, err = int96ToTime(v)
+	case kindInterval:
+		res, err = bytesToInterval(v)
+	case kindDecimal:
+		res, err = decimalToLogical(v, c.scale)
+	}
+
+	if err != nil {
+		return nil, errors.WithFields(err, errors.Fields{
+			"column": elem.GetName(),
+		})
+	}
+
+	return res, nil
+}
+
+// ToPhysical converts v, a value of the column described by elem, from its logical
+// representation, as returned by ToLogical, to the physical representation expected
+// by the writer. The physical values, and nil, are returned unchanged. The decimals are
+// rescaled to the scale of the column, and fail to be converted if they exceed its precision.
+func ToPhysical(elem *parquet.SchemaElement, v interface{}, opts *Options) (interface{}, error) {
+	c := conversionOf(elem)
+	if c.kind == kindNone || v == nil {
+		return v, nil
+	}
+
+	var (
+		res interface{}
+		err error
+	)
+
+	switch c.kind { //nolint:exhaustive // kindNone is handled above
+	case kindDate:
+		res, err = timeToDate(v)
+	case kindTimestamp:
+		res, err = timeToTimestamp(v, c.unit, c.utc, opts.location())
+	case kindTime:
+		res, err = durationToTime(v, c.unit)
+	case kindInt96:
+		res, err = timeToInt96(v)
+	case kindInterval:
+		res, err = intervalToBytes(v)
+	case kindDecimal:
+		res, err = decimalToPhysical(elem, v, c.precision, c.scale)
+	}
+
+	if err != nil {
+		return nil, errors.WithFields(err, errors.Fields{
+			"column": elem.GetName(),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,162 @@
+package logical
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func element(typ parquet.Type, logical *parquet.LogicalType, converted *parquet.ConvertedType) *parquet.SchemaElement {
+	return &parquet.SchemaElement{
+		Name:          "col",
+		Type:          parquet.TypePtr(typ),
+		LogicalType:   logical,
+		ConvertedType: converted,
+	}
+}
+
+func timestampType(utc bool, u *parquet.TimeUnit) *parquet.LogicalType {
+	return &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: u}}
+}
+
+func timeType(u *parquet.TimeUnit) *parquet.LogicalType {
+	return &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: true, Unit: u}}
+}
+
+//nolint:gochecknoglobals // Read-only test fixtures.
+var (
+	millis = &parquet.TimeUnit{MILLIS: parquet.NewMilliSeconds()}
+	micros = &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()}
+	nanos  = &parquet.TimeUnit{NANOS: parquet.NewNanoSeconds()}
+)
+
+func TestToLogical(t *testing.T) {
+	t.Run("Date", TestToLogical_Date)
+	t.Run("Timestamp", TestToLogical_Timestamp)
+	t.Run("LocalTimestamp", TestToLogical_LocalTimestamp)
+	t.Run("Time", TestToLogical_Time)
+	t.Run("Int96", TestToLogical_Int96)
+	t.Run("Interval", TestToLogical_Interval)
+	t.Run("Unchanged", TestToLogical_Unchanged)
+	t.Run("UnexpectedType", TestToLogical_UnexpectedType)
+}
+
+// roundTrip checks the conversion of physical to logical, and back.
+func roundTrip(t *testing.T, elem *parquet.SchemaElement, opts *Options, physical, expected interface{}) {
+	t.Helper()
+
+	v, err := ToLogical(elem, physical, opts)
+	require.NoError(t, err)
+	assert.Equal(t, expected, v)
+
+	p, err := ToPhysical(elem, v, opts)
+	require.NoError(t, err)
+	assert.Equal(t, physical, p)
+}
+
+func TestToLogical_Date(t *testing.T) {
+	t.Parallel()
+
+	for _, elem := range []*parquet.SchemaElement{
+		element(parquet.Type_INT32, &parquet.LogicalType{DATE: parquet.NewDateType()}, nil),
+		element(parquet.Type_INT32, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)),
+	} {
+		roundTrip(t, elem, nil, int32(18628), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+		roundTrip(t, elem, nil, int32(-1), time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC))
+	}
+}
+
+func TestToLogical_Timestamp(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2021, 2, 3, 4, 5, 6, 789123456, time.UTC)
+
+	roundTrip(t, element(parquet.Type_INT64, timestampType(true, millis), nil), nil, int64(1612325106789), ts.Truncate(time.Millisecond))
+	roundTrip(t, element(parquet.Type_INT64, timestampType(true, micros), nil), nil, int64(1612325106789123), ts.Truncate(time.Microsecond))
+	roundTrip(t, element(parquet.Type_INT64, timestampType(true, nanos), nil), nil, int64(1612325106789123456), ts)
+
+	roundTrip(t, element(parquet.Type_INT64, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MILLIS)), nil,
+		int64(1612325106789), ts.Truncate(time.Millisecond))
+	roundTrip(t, element(parquet.Type_INT64, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MICROS)), nil,
+		int64(1612325106789123), ts.Truncate(time.Microsecond))
+
+	// Before the epoch.
+	roundTrip(t, element(parquet.Type_INT64, timestampType(true, millis), nil), nil, int64(-1), time.Date(1969, 12, 31, 23, 59, 59, 999000000, time.UTC))
+
+	// An instant in another location is converted to UTC.
+	v, err := ToPhysical(element(parquet.Type_INT64, timestampType(true, millis), nil), ts.In(time.FixedZone("X", 3600)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1612325106789), v)
+}
+
+func TestToLogical_LocalTimestamp(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("X", -5*3600)
+	elem := element(parquet.Type_INT64, timestampType(false, millis), nil)
+
+	roundTrip(t, elem, nil, int64(1612325106000), time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC))
+	roundTrip(t, elem, &Options{Location: loc}, int64(1612325106000), time.Date(2021, 2, 3, 4, 5, 6, 0, loc))
+}
+
+func TestToLogical_Time(t *testing.T) {
+	t.Parallel()
+
+	d := 4*time.Hour + 5*time.Minute + 6*time.Second + 789*time.Millisecond
+
+	roundTrip(t, element(parquet.Type_INT32, timeType(millis), nil), nil, int32(d/time.Millisecond), d)
+	roundTrip(t, element(parquet.Type_INT64, timeType(micros), nil), nil, int64(d/time.Microsecond), d)
+	roundTrip(t, element(parquet.Type_INT64, timeType(nanos), nil), nil, int64(d), d)
+	roundTrip(t, element(parquet.Type_INT32, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_TIME_MILLIS)), nil, int32(d/time.Millisecond), d)
+	roundTrip(t, element(parquet.Type_INT64, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_TIME_MICROS)), nil, int64(d/time.Microsecond), d)
+}
+
+func TestToLogical_Int96(t *testing.T) {
+	t.Parallel()
+
+	// 2021-02-03 04:05:06.789 UTC, Julian day 2459249.
+	b := [12]byte{0x40, 0x23, 0xef, 0x30, 0x60, 0x0d, 0x00, 0x00, 0x71, 0x86, 0x25, 0x00}
+
+	roundTrip(t, element(parquet.Type_INT96, nil, nil), nil, b, time.Date(2021, 2, 3, 4, 5, 6, 789000000, time.UTC))
+}
+
+func TestToLogical_Interval(t *testing.T) {
+	t.Parallel()
+
+	elem := element(parquet.Type_FIXED_LEN_BYTE_ARRAY, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_INTERVAL))
+	b := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 1, 0, 0}
+
+	roundTrip(t, elem, nil, b, Interval{Months: 1, Days: 2, Milliseconds: 259})
+
+	_, err := ToLogical(elem, []byte{1, 2, 3}, nil)
+	assert.Error(t, err)
+}
+
+func TestToLogical_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	elem := element(parquet.Type_INT64, nil, nil)
+
+	assert.False(t, HasConversion(elem))
+	roundTrip(t, elem, nil, int64(42), int64(42))
+
+	v, err := ToLogical(element(parquet.Type_INT32, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)), nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestToLogical_UnexpectedType(t *testing.T) {
+	t.Parallel()
+
+	_, err := ToLogical(element(parquet.Type_INT32, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)), "x", nil)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, errUnexpectedType))
+
+	_, err = ToPhysical(element(parquet.Type_INT32, nil, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)), "x", nil)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, errUnexpectedType))
+}
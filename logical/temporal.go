@@ -0,0 +1,203 @@
+package logical
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/hexbee-net/errors"
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+
+	// julianDayOfEpoch is the Julian day number of 1970-01-01.
+	julianDayOfEpoch = 2440588
+
+	int96Size    = 12
+	intervalSize = 12
+)
+
+// Interval is the value of an INTERVAL column. Its parts are independent: a month
+// doesn't have a fixed number of days, nor a day a fixed number of milliseconds.
+type Interval struct {
+	Months       uint32
+	Days         uint32
+	Milliseconds uint32
+}
+
+func unexpectedType(v interface{}, expected string) error {
+	return errors.WithFields(
+		errors.WithStack(errUnexpectedType),
+		errors.Fields{
+			"type":     fmt.Sprintf("%T", v),
+			"expected": expected,
+		})
+}
+
+// floorDiv returns the quotient and the remainder of a/b, rounding the quotient down.
+func floorDiv(a, b int64) (q, r int64) {
+	q, r = a/b, a%b
+	if r < 0 {
+		q--
+		r += b
+	}
+
+	return q, r
+}
+
+func dateToTime(v interface{}) (interface{}, error) {
+	days, ok := v.(int32)
+	if !ok {
+		return nil, unexpectedType(v, "int32")
+	}
+
+	return time.Unix(int64(days)*secondsPerDay, 0).UTC(), nil
+}
+
+func timeToDate(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case int32:
+		return t, nil
+	case time.Time:
+		y, m, d := t.Date()
+		days, _ := floorDiv(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix(), secondsPerDay)
+
+		return int32(days), nil
+	default:
+		return nil, unexpectedType(v, "time.Time")
+	}
+}
+
+func timestampToTime(v interface{}, u unit, utc bool, loc *time.Location) (interface{}, error) {
+	n, ok := v.(int64)
+	if !ok {
+		return nil, unexpectedType(v, "int64")
+	}
+
+	perSecond := int64(time.Second) / int64(u)
+	sec, rem := floorDiv(n, perSecond)
+	t := time.Unix(sec, rem*int64(u)).UTC()
+
+	if utc {
+		return t, nil
+	}
+
+	// The value is a wall clock time, with no time zone.
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), nil
+}
+
+func timeToTimestamp(v interface{}, u unit, utc bool, loc *time.Location) (interface{}, error) {
+	switch t := v.(type) {
+	case int64:
+		return t, nil
+	case time.Time:
+		if !utc {
+			t = t.In(loc)
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+		}
+
+		perSecond := int64(time.Second) / int64(u)
+
+		return t.Unix()*perSecond + int64(t.Nanosecond())/int64(u), nil
+	default:
+		return nil, unexpectedType(v, "time.Time")
+	}
+}
+
+func timeToDuration(v interface{}, u unit) (interface{}, error) {
+	switch n := v.(type) {
+	case int32:
+		return time.Duration(n) * time.Duration(u), nil
+	case int64:
+		return time.Duration(n) * time.Duration(u), nil
+	default:
+		return nil, unexpectedType(v, "int32 or int64")
+	}
+}
+
+func durationToTime(v interface{}, u unit) (interface{}, error) {
+	switch d := v.(type) {
+	case int32, int64:
+		return d, nil
+	case time.Duration:
+		if u == unitMillis {
+			return int32(d / time.Duration(u)), nil
+		}
+
+		return int64(d / time.Duration(u)), nil
+	default:
+		return nil, unexpectedType(v, "time.Duration")
+	}
+}
+
+// int96ToTime converts an Impala timestamp: the nanoseconds of the day followed by the
+// Julian day, both little endian.
+func int96ToTime(v interface{}) (interface{}, error) {
+	b, ok := v.([int96Size]byte)
+	if !ok {
+		return nil, unexpectedType(v, "[12]byte")
+	}
+
+	nanos := int64(binary.LittleEndian.Uint64(b[:8]))
+	days := int64(binary.LittleEndian.Uint32(b[8:])) - julianDayOfEpoch
+
+	return time.Unix(days*secondsPerDay, nanos).UTC(), nil
+}
+
+func timeToInt96(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case [int96Size]byte:
+		return t, nil
+	case time.Time:
+		days, nanos := floorDiv(t.Unix(), secondsPerDay)
+		nanos = nanos*int64(time.Second) + int64(t.Nanosecond())
+
+		var b [int96Size]byte
+
+		binary.LittleEndian.PutUint64(b[:8], uint64(nanos))
+		binary.LittleEndian.PutUint32(b[8:], uint32(days+julianDayOfEpoch))
+
+		return b, nil
+	default:
+		return nil, unexpectedType(v, "time.Time")
+	}
+}
+
+func bytesToInterval(v interface{}) (interface{}, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, unexpectedType(v, "[]byte")
+	}
+
+	if len(b) != intervalSize {
+		return nil, errors.WithFields(
+			errors.WithStack(errInvalidValue),
+			errors.Fields{
+				"size": len(b),
+			})
+	}
+
+	return Interval{
+		Months:       binary.LittleEndian.Uint32(b[0:4]),
+		Days:         binary.LittleEndian.Uint32(b[4:8]),
+		Milliseconds: binary.LittleEndian.Uint32(b[8:12]),
+	}, nil
+}
+
+func intervalToBytes(v interface{}) (interface{}, error) {
+	switch i := v.(type) {
+	case []byte:
+		return i, nil
+	case Interval:
+		b := make([]byte, intervalSize)
+
+		binary.LittleEndian.PutUint32(b[0:4], i.Months)
+		binary.LittleEndian.PutUint32(b[4:8], i.Days)
+		binary.LittleEndian.PutUint32(b[8:12], i.Milliseconds)
+
+		return b, nil
+	default:
+		return nil, unexpectedType(v, "logical.Interval")
+	}
+}
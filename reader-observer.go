@@ -0,0 +1,109 @@
+package parquet
+
+import (
+	"time"
+
+	"github.com/hexbee-net/parquet/layout"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/hexbee-net/parquet/schema"
+)
+
+// RowGroupEvent describes a row group loaded by a FileReader.
+type RowGroupEvent struct {
+	// Index is the position of the row group in the file.
+	Index int
+	// NumRows is the number of rows of the row group.
+	NumRows int64
+	// TotalByteSize is the uncompressed size of the row group data.
+	TotalByteSize int64
+	// Duration is the time spent loading the row group, set on RowGroupEnd only.
+	Duration time.Duration
+	// Err is the error that interrupted the loading, set on RowGroupEnd only.
+	Err error
+}
+
+// ColumnChunkEvent describes a column chunk read by a FileReader.
+// Column is the path of the column, as returned by schema.Column.Path.
+type ColumnChunkEvent struct {
+	RowGroup         int
+	Column           []string
+	Codec            parquet.CompressionCodec
+	CompressedSize   int64
+	UncompressedSize int64
+	NumValues        int64
+	Pages            int
+	Duration         time.Duration
+}
+
+// PageEvent describes a page read by a FileReader: its data is decompressed
+// and its decoders are initialized, its values are decoded later on as the
+// rows are read. Column is the path of the column, as returned by schema.Column.Path.
+type PageEvent struct {
+	RowGroup         int
+	Column           []string
+	Type             parquet.PageType
+	Encoding         parquet.Encoding
+	NumValues        int32
+	CompressedSize   int32
+	UncompressedSize int32
+	Duration         time.Duration
+}
+
+// ReaderObserver receives the lifecycle events of a FileReader, e.g. to trace them.
+// The column chunk, page and dictionary page events may be called concurrently
+// for different columns when the source supports positioned reads.
+type ReaderObserver interface {
+	RowGroupStart(e RowGroupEvent)
+	RowGroupEnd(e RowGroupEvent)
+	ColumnChunkRead(e ColumnChunkEvent)
+	PageRead(e PageEvent)
+	DictionaryPageLoaded(e PageEvent)
+}
+
+// NopReaderObserver is a ReaderObserver ignoring all the events.
+// It can be embedded to implement only some of the methods.
+type NopReaderObserver struct{}
+
+func (NopReaderObserver) RowGroupStart(RowGroupEvent)      {}
+func (NopReaderObserver) RowGroupEnd(RowGroupEvent)        {}
+func (NopReaderObserver) ColumnChunkRead(ColumnChunkEvent) {}
+func (NopReaderObserver) PageRead(PageEvent)               {}
+func (NopReaderObserver) DictionaryPageLoaded(PageEvent)   {}
+
+// chunkObserver forwards the events of the layout.ChunkReader to a ReaderObserver.
+type chunkObserver struct {
+	observer ReaderObserver
+	rowGroup int
+}
+
+func (o *chunkObserver) ChunkRead(col *schema.Column, chunk *parquet.ColumnChunk, pages int, duration time.Duration) {
+	o.observer.ColumnChunkRead(ColumnChunkEvent{
+		RowGroup:         o.rowGroup,
+		Column:           col.Path(),
+		Codec:            chunk.MetaData.Codec,
+		CompressedSize:   chunk.MetaData.TotalCompressedSize,
+		UncompressedSize: chunk.MetaData.TotalUncompressedSize,
+		NumValues:        chunk.MetaData.NumValues,
+		Pages:            pages,
+		Duration:         duration,
+	})
+}
+
+func (o *chunkObserver) PageRead(col *schema.Column, header *parquet.PageHeader, duration time.Duration) {
+	e := PageEvent{
+		RowGroup:         o.rowGroup,
+		Column:           col.Path(),
+		Type:             header.Type,
+		Encoding:         layout.PageEncoding(header),
+		NumValues:        layout.PageNumValues(header),
+		CompressedSize:   header.CompressedPageSize,
+		UncompressedSize: header.UncompressedPageSize,
+		Duration:         duration,
+	}
+
+	if header.Type == parquet.PageType_DICTIONARY_PAGE {
+		o.observer.DictionaryPageLoaded(e)
+	} else {
+		o.observer.PageRead(e)
+	}
+}
@@ -0,0 +1,112 @@
+package parquet
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderObserver(t *testing.T) {
+	t.Run("Events", TestReaderObserver_Events)
+	t.Run("Disabled", TestReaderObserver_Disabled)
+}
+
+// recordingObserver records the events it receives as strings.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) record(format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = append(o.events, fmt.Sprintf(format, args...))
+}
+
+func (o *recordingObserver) RowGroupStart(e RowGroupEvent) {
+	o.record("start %d rows=%d", e.Index, e.NumRows)
+}
+
+func (o *recordingObserver) RowGroupEnd(e RowGroupEvent) {
+	o.record("end %d err=%v", e.Index, e.Err)
+}
+
+func (o *recordingObserver) ColumnChunkRead(e ColumnChunkEvent) {
+	o.record("chunk %d %v pages=%d values=%d", e.RowGroup, e.Column, e.Pages, e.NumValues)
+}
+
+func (o *recordingObserver) PageRead(e PageEvent) {
+	o.record("page %d %v %s values=%d", e.RowGroup, e.Column, e.Type, e.NumValues)
+}
+
+func (o *recordingObserver) DictionaryPageLoaded(e PageEvent) {
+	o.record("dictionary %d %v", e.RowGroup, e.Column)
+}
+
+func TestReaderObserver_Events(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 6, 2)
+	file.corrupt(1, 1, 1)
+
+	r, err := NewFileReader(memory.NewReader(file.data))
+	require.NoError(t, err)
+
+	o := &recordingObserver{}
+
+	r.SetObserver(o)
+	r.SetConcurrency(1) // the chunks are read in order
+	r.SetLenient(&LenientOptions{
+		Level: SkipPage,
+		OnSkip: func(e SkipEvent) {
+			o.record("skip %d %v page=%d rows=%d+%d", e.RowGroup, e.Column, e.Page, e.FirstRow, e.NumRows)
+		},
+	})
+
+	rows, err := readRows(r)
+	require.NoError(t, err)
+	assert.Len(t, rows, 12)
+
+	assert.Equal(t, []string{
+		"start 0 rows=6",
+		"page 0 [id] DATA_PAGE values=3",
+		"page 0 [id] DATA_PAGE values=3",
+		"chunk 0 [id] pages=2 values=6",
+		"page 0 [a] DATA_PAGE values=3",
+		"page 0 [a] DATA_PAGE values=3",
+		"chunk 0 [a] pages=2 values=6",
+		"end 0 err=<nil>",
+		"start 1 rows=6",
+		"page 1 [id] DATA_PAGE values=3",
+		"page 1 [id] DATA_PAGE values=3",
+		"chunk 1 [id] pages=2 values=6",
+		"page 1 [a] DATA_PAGE values=3",
+		"chunk 1 [a] pages=2 values=6",
+		"skip 1 [a] page=1 rows=3+3",
+		"end 1 err=<nil>",
+	}, o.events)
+}
+
+func TestReaderObserver_Disabled(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 6, 2)
+
+	r, err := NewFileReader(memory.NewReader(file.data))
+	require.NoError(t, err)
+
+	o := &recordingObserver{}
+
+	r.SetObserver(o)
+	r.SetObserver(nil)
+
+	rows, err := readRows(r)
+	require.NoError(t, err)
+	assert.Len(t, rows, 12)
+	assert.Empty(t, o.events)
+}
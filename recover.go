@@ -0,0 +1,534 @@
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/compression"
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/layout"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/hexbee-net/parquet/schema"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const recoveredBy = "github.com/hexbee-net/parquet recovery"
+
+// DefaultRecoverCodecs are the compression codecs tried on the pages of a recovered file, in order.
+//
+//nolint:gochecknoglobals // Read-only default configuration.
+var DefaultRecoverCodecs = []parquet.CompressionCodec{
+	parquet.CompressionCodec_UNCOMPRESSED,
+	parquet.CompressionCodec_SNAPPY,
+	parquet.CompressionCodec_ZSTD,
+	parquet.CompressionCodec_GZIP,
+	parquet.CompressionCodec_LZ4,
+	parquet.CompressionCodec_BROTLI,
+}
+
+// RecoverOptions configures the recovery of a file without a readable footer.
+type RecoverOptions struct {
+	// RowGroupRows is the number of rows of the row groups, as configured in the writer,
+	// the last row group may be smaller. When 0, the row groups are inferred from the
+	// number of rows of the pages, which may be ambiguous (see Recovery.Ambiguous).
+	RowGroupRows int64
+	// Codecs are the compression codecs tried on the pages, in order.
+	// DefaultRecoverCodecs is used when empty.
+	Codecs []parquet.CompressionCodec
+}
+
+// Recovery holds the file meta-data rebuilt by RecoverFile.
+type Recovery struct {
+	// MetaData is the rebuilt file meta-data.
+	MetaData *parquet.FileMetaData
+	// DataEnd is the offset of the end of the last recovered row group.
+	// The data after it is lost.
+	DataEnd int64
+	// Size is the size of the file.
+	Size int64
+	// Ambiguous is true when the pages could be grouped in row groups in several ways.
+	// The first grouping found is used, RecoverOptions.RowGroupRows removes the ambiguity.
+	Ambiguous bool
+
+	reader source.Reader
+}
+
+// RecoverFile rebuilds the meta-data of a file whose footer is missing or truncated,
+// for example because its writer crashed, given the schema it was written with.
+//
+// The page headers are read from the start of the file up to the first one that
+// can't be read. The pages are then grouped in column chunks, following the order of
+// the columns of the schema, and in row groups whose column chunks hold the same number
+// of rows. A grouping is only accepted if all its pages can be decoded. The pages after
+// the last complete row group are lost.
+func RecoverFile(r source.Reader, def *schema.SchemaDefinition, opts *RecoverOptions) (*Recovery, error) {
+	if opts == nil {
+		opts = &RecoverOptions{}
+	}
+
+	codecs := opts.Codecs
+	if len(codecs) == 0 {
+		codecs = DefaultRecoverCodecs
+	}
+
+	createdBy := recoveredBy
+	meta := &parquet.FileMetaData{
+		Version:   1,
+		Schema:    def.SchemaElements(),
+		CreatedBy: &createdBy,
+	}
+
+	s, err := readFileSchema(meta, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid schema definition")
+	}
+
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to seek to the end of the file")
+	}
+
+	if err := readMagicHeader(r); err != nil {
+		return nil, err
+	}
+
+	pages, err := layout.ScanPages(context.Background(), r, int64(magicLen), size)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := source.NewReaderAt(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create positioned reader")
+	}
+
+	s.ResetData()
+
+	rc := &recoverer{
+		reader:      r,
+		at:          at,
+		columns:     s.Columns(),
+		pages:       pages,
+		codecs:      codecs,
+		compressors: defaultCompressors(),
+		chunkReader: layout.NewChunkReader(defaultCompressors()),
+		data:        make(map[int]*scannedData),
+		rows:        make(map[pageKey]scannedRows),
+		chunks:      make(map[chunkKey]scannedChunk),
+		decoded:     make(map[decodeKey]error),
+	}
+
+	rec := &Recovery{
+		MetaData: meta,
+		DataEnd:  int64(magicLen),
+		Size:     size,
+		reader:   r,
+	}
+
+	for pos := 0; pos < len(pages) && len(rc.columns) > 0; {
+		rg, next, ambiguous := rc.rowGroup(pos, opts.RowGroupRows)
+		if rg == nil {
+			break
+		}
+
+		meta.RowGroups = append(meta.RowGroups, rg)
+		meta.NumRows += rg.NumRows
+
+		rec.DataEnd = pages[next-1].End()
+		rec.Ambiguous = rec.Ambiguous || ambiguous
+		pos = next
+	}
+
+	return rec, nil
+}
+
+// NewFileReader creates a FileReader reading the recovered row groups.
+func (rec *Recovery) NewFileReader(columns ...string) (*FileReader, error) {
+	if _, err := rec.reader.Seek(int64(magicLen), io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return newFileReader(rec.reader, rec.MetaData, false, columns...)
+}
+
+// WriteTo writes the repaired file to w: the recovered row groups followed by the rebuilt footer.
+func (rec *Recovery) WriteTo(w io.Writer) (int64, error) {
+	if _, err := rec.reader.Seek(0, io.SeekStart); err != nil {
+		return 0, errors.Wrap(err, "failed to seek to the start of the file")
+	}
+
+	n, err := io.CopyN(w, rec.reader, rec.DataEnd)
+	if err != nil {
+		return n, errors.Wrap(err, "failed to copy the row groups")
+	}
+
+	footer := &bytes.Buffer{}
+	if err := writeThrift(rec.MetaData, footer); err != nil {
+		return n, errors.Wrap(err, "failed to encode file meta data")
+	}
+
+	if err := binary.Write(footer, binary.LittleEndian, int32(footer.Len())); err != nil {
+		return n, err
+	}
+
+	footer.WriteString(magic)
+
+	m, err := w.Write(footer.Bytes())
+
+	return n + int64(m), err
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+
+type scannedData struct {
+	data  []byte
+	codec parquet.CompressionCodec
+	err   error
+}
+
+type scannedRows struct {
+	rows int64
+	err  error
+}
+
+type scannedChunk struct {
+	chunk *parquet.ColumnChunk
+	err   error
+}
+
+type pageKey struct {
+	col  *schema.Column
+	page int
+}
+
+type chunkKey struct {
+	col      *schema.Column
+	pos, end int
+}
+
+type decodeKey struct {
+	col        *schema.Column
+	dict, page int
+	codec      parquet.CompressionCodec
+}
+
+// recoverer groups the scanned pages in row groups. The candidate groupings share
+// most of their pages, so the data, the number of rows and the decoding of the pages
+// are cached to read and decode each of them once.
+type recoverer struct {
+	reader      source.Reader
+	at          source.ReaderAt
+	columns     []*schema.Column
+	pages       []layout.ScannedPage
+	codecs      []parquet.CompressionCodec
+	compressors map[parquet.CompressionCodec]compression.BlockCompressor
+	chunkReader *layout.ChunkReader
+	data        map[int]*scannedData
+	rows        map[pageKey]scannedRows
+	chunks      map[chunkKey]scannedChunk
+	decoded     map[decodeKey]error
+}
+
+// rowGroup finds the row group made of the pages starting at pos. It returns nil if
+// there is none, else the row group, the position of the next page, and whether
+// another grouping was possible.
+func (rc *recoverer) rowGroup(pos int, rowsHint int64) (rg *parquet.RowGroup, next int, ambiguous bool) {
+	// The candidates are the possible ends of the first column chunk.
+	var candidates []int
+
+	for end := pos + 1; end <= len(rc.pages); end++ {
+		if end > pos+1 && rc.pages[end-1].IsDictionary() {
+			break
+		}
+
+		candidates = append(candidates, end)
+	}
+
+	if rowsHint > 0 {
+		// Try the full row groups first, the last one may be smaller.
+		var full, other []int
+
+		for _, end := range candidates {
+			rows, err := rc.chunkRows(rc.columns[0], pos, end)
+
+			switch {
+			case err != nil || rows > rowsHint:
+			case rows == rowsHint:
+				full = append(full, end)
+			default:
+				other = append(other, end)
+			}
+		}
+
+		candidates = append(full, other...)
+	}
+
+	for _, end := range candidates {
+		res, n, ok := rc.tryRowGroup(pos, end)
+		if !ok {
+			continue
+		}
+
+		if rg != nil {
+			return rg, next, true
+		}
+
+		rg, next = res, n
+
+		if rowsHint > 0 {
+			break
+		}
+	}
+
+	return rg, next, false
+}
+
+// tryRowGroup tries to build the row group whose first column chunk is made of the pages from pos to end.
+func (rc *recoverer) tryRowGroup(pos, end int) (*parquet.RowGroup, int, bool) {
+	rows, err := rc.chunkRows(rc.columns[0], pos, end)
+	if err != nil || rows == 0 {
+		return nil, 0, false
+	}
+
+	// The pages are only decoded once the chunks of all the columns hold the same number of rows.
+	ends := []int{end}
+
+	for _, col := range rc.columns[1:] {
+		if end = rc.chunkEnd(col, end, rows); end < 0 {
+			return nil, 0, false
+		}
+
+		ends = append(ends, end)
+	}
+
+	rg := &parquet.RowGroup{NumRows: rows}
+	start := pos
+
+	for i, col := range rc.columns {
+		chunk, err := rc.chunk(col, start, ends[i])
+		if err != nil {
+			return nil, 0, false
+		}
+
+		rg.Columns = append(rg.Columns, chunk)
+		rg.TotalByteSize += chunk.MetaData.TotalUncompressedSize
+		start = ends[i]
+	}
+
+	return rg, end, true
+}
+
+// chunkEnd returns the end of the pages from pos holding the given number of rows of col, or -1.
+func (rc *recoverer) chunkEnd(col *schema.Column, pos int, rows int64) int {
+	var n int64
+
+	for end := pos + 1; end <= len(rc.pages); end++ {
+		if end > pos+1 && rc.pages[end-1].IsDictionary() {
+			return -1
+		}
+
+		r, err := rc.pageRows(col, end-1)
+		if err != nil {
+			return -1
+		}
+
+		if n += r; n == rows {
+			return end
+		} else if n > rows {
+			return -1
+		}
+	}
+
+	return -1
+}
+
+func (rc *recoverer) chunkRows(col *schema.Column, pos, end int) (int64, error) {
+	var rows int64
+
+	for i := pos; i < end; i++ {
+		r, err := rc.pageRows(col, i)
+		if err != nil {
+			return 0, err
+		}
+
+		rows += r
+	}
+
+	return rows, nil
+}
+
+func (rc *recoverer) pageRows(col *schema.Column, i int) (int64, error) {
+	key := pageKey{col: col, page: i}
+	if r, ok := rc.rows[key]; ok {
+		return r.rows, r.err
+	}
+
+	var r scannedRows
+
+	if d := rc.pageData(i); d.err != nil {
+		r.err = d.err
+	} else {
+		r.rows, r.err = layout.PageRows(rc.pages[i], col.MaxRepetitionLevel(), d.data, rc.compressors[d.codec])
+	}
+
+	rc.rows[key] = r
+
+	return r.rows, r.err
+}
+
+// pageData reads the data of the page i and detects its codec.
+func (rc *recoverer) pageData(i int) *scannedData {
+	if d, ok := rc.data[i]; ok {
+		return d
+	}
+
+	d := &scannedData{}
+
+	d.data, d.err = layout.ReadScannedPage(rc.at, rc.pages[i])
+	if d.err == nil {
+		d.codec, d.err = layout.DetectCodec(rc.pages[i], d.data, rc.codecs, rc.compressors)
+	}
+
+	rc.data[i] = d
+
+	return d
+}
+
+// chunk returns the column chunk of col made of the pages from pos to end, if its pages can be decoded.
+func (rc *recoverer) chunk(col *schema.Column, pos, end int) (*parquet.ColumnChunk, error) {
+	key := chunkKey{col: col, pos: pos, end: end}
+	if c, ok := rc.chunks[key]; ok {
+		return c.chunk, c.err
+	}
+
+	chunk, err := rc.buildChunk(col, pos, end)
+	rc.chunks[key] = scannedChunk{chunk: chunk, err: err}
+
+	return chunk, err
+}
+
+// buildChunk builds the column chunk of col made of the pages from pos to end, and checks its pages can be decoded.
+func (rc *recoverer) buildChunk(col *schema.Column, pos, end int) (*parquet.ColumnChunk, error) {
+	meta := &parquet.ColumnMetaData{
+		Type:         col.Element().GetType(),
+		PathInSchema: col.Path(),
+		Codec:        rc.pageData(pos).codec,
+	}
+
+	encodings := make(map[parquet.Encoding]bool)
+	addEncoding := func(enc parquet.Encoding) {
+		if !encodings[enc] {
+			encodings[enc] = true
+			meta.Encodings = append(meta.Encodings, enc)
+		}
+	}
+
+	for i := pos; i < end; i++ {
+		p := rc.pages[i]
+
+		if d := rc.pageData(i); d.err != nil || d.codec != meta.Codec && len(d.data) > 0 {
+			return nil, errs.New(errs.ErrCorruptPage, "pages with different codecs")
+		}
+
+		meta.TotalCompressedSize += p.HeaderSize + int64(p.Header.CompressedPageSize)
+		meta.TotalUncompressedSize += p.HeaderSize + int64(p.Header.UncompressedPageSize)
+
+		addEncoding(layout.PageEncoding(p.Header))
+
+		if p.IsDictionary() {
+			offset := p.Offset
+			meta.DictionaryPageOffset = &offset
+
+			continue
+		}
+
+		if meta.DataPageOffset == 0 {
+			meta.DataPageOffset = p.Offset
+		}
+
+		meta.NumValues += int64(layout.PageNumValues(p.Header))
+
+		if h := p.Header.DataPageHeader; h != nil {
+			addEncoding(h.DefinitionLevelEncoding)
+			addEncoding(h.RepetitionLevelEncoding)
+		}
+	}
+
+	if meta.DataPageOffset == 0 {
+		return nil, errs.New(errs.ErrCorruptPage, "column chunk without data page")
+	}
+
+	dict := -1
+	if rc.pages[pos].IsDictionary() {
+		dict = pos
+	}
+
+	for i := pos; i < end; i++ {
+		if i == dict {
+			continue
+		}
+
+		if err := rc.decodePage(col, dict, i, meta.Codec); err != nil {
+			return nil, err
+		}
+	}
+
+	return &parquet.ColumnChunk{
+		FileOffset: rc.pages[pos].Offset,
+		MetaData:   meta,
+	}, nil
+}
+
+// decodePage checks that the values of the data page i of col can be read, with the
+// dictionary page dict, or without dictionary if it is negative. The data pages are
+// decoded on their own, so that the chunks sharing them don't decode them again.
+func (rc *recoverer) decodePage(col *schema.Column, dict, i int, codec parquet.CompressionCodec) error {
+	key := decodeKey{col: col, dict: dict, page: i, codec: codec}
+	if err, ok := rc.decoded[key]; ok {
+		return err
+	}
+
+	p := rc.pages[i]
+	meta := &parquet.ColumnMetaData{
+		Type:                col.Element().GetType(),
+		PathInSchema:        col.Path(),
+		Codec:               codec,
+		DataPageOffset:      p.Offset,
+		TotalCompressedSize: p.End() - p.Offset,
+	}
+
+	if dict >= 0 {
+		// The chunk reader skips the pages between the dictionary page and the data page.
+		offset := rc.pages[dict].Offset
+		meta.DictionaryPageOffset = &offset
+		meta.TotalCompressedSize = p.End() - offset
+	}
+
+	err := rc.decode(col, &parquet.ColumnChunk{FileOffset: meta.DataPageOffset, MetaData: meta})
+	rc.decoded[key] = err
+
+	return err
+}
+
+// decode checks that all the values of the chunk can be read.
+func (rc *recoverer) decode(col *schema.Column, chunk *parquet.ColumnChunk) error {
+	pages, err := rc.chunkReader.ReadChunk(rc.reader, col, chunk)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pages {
+		if p.NumValues() == 0 {
+			continue
+		}
+
+		if _, _, _, err := readPageValues(col, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
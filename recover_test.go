@@ -0,0 +1,216 @@
+package parquet
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/hexbee-net/parquet/schema"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchemaDefinition(t *testing.T, file *testFile) *schema.SchemaDefinition {
+	t.Helper()
+
+	r, err := NewFileReader(memory.NewReader(file.data))
+	require.NoError(t, err)
+
+	return r.GetSchemaDefinition()
+}
+
+func readRecoveredRows(t *testing.T, rec *Recovery) []map[string]interface{} {
+	t.Helper()
+
+	r, err := rec.NewFileReader()
+	require.NoError(t, err)
+
+	var rows []map[string]interface{}
+
+	for {
+		row, err := r.NextRow()
+		if err == io.EOF {
+			return rows
+		}
+
+		require.NoError(t, err)
+
+		rows = append(rows, row)
+	}
+}
+
+func assertTestRows(t *testing.T, rows []map[string]interface{}) {
+	t.Helper()
+
+	for i, row := range rows {
+		assert.Equal(t, int64(i), row["id"])
+
+		if i%3 == 0 {
+			assert.NotContains(t, row, "a")
+		} else {
+			assert.Equal(t, int64(i*2), row["a"])
+		}
+	}
+}
+
+func TestRecoverFile(t *testing.T) {
+	t.Run("MissingFooter", TestRecoverFile_MissingFooter)
+	t.Run("TruncatedRowGroup", TestRecoverFile_TruncatedRowGroup)
+	t.Run("RowGroupRows", TestRecoverFile_RowGroupRows)
+	t.Run("WriteTo", TestRecoverFile_WriteTo)
+	t.Run("NotParquet", TestRecoverFile_NotParquet)
+	t.Run("ManyPages", TestRecoverFile_ManyPages)
+}
+
+func TestRecoverFile_MissingFooter(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	def := testSchemaDefinition(t, file)
+	end := file.pages[1][1][2][1]
+
+	rec, err := RecoverFile(memory.NewReader(file.data[:end]), def, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(end), rec.DataEnd)
+	assert.Equal(t, int64(60), rec.MetaData.NumRows)
+	assert.Len(t, rec.MetaData.RowGroups, 2)
+	assert.False(t, rec.Ambiguous)
+
+	rows := readRecoveredRows(t, rec)
+	require.Len(t, rows, 60)
+	assertTestRows(t, rows)
+}
+
+func TestRecoverFile_TruncatedRowGroup(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	def := testSchemaDefinition(t, file)
+
+	// The file ends in the middle of the last page of the second row group.
+	end := file.pages[1][1][2][0] + 2
+
+	rec, err := RecoverFile(memory.NewReader(file.data[:end]), def, &RecoverOptions{RowGroupRows: 30})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(file.pages[0][1][2][1]), rec.DataEnd)
+	require.Len(t, rec.MetaData.RowGroups, 1)
+	assert.Equal(t, int64(30), rec.MetaData.NumRows)
+	assert.False(t, rec.Ambiguous)
+
+	rows := readRecoveredRows(t, rec)
+	require.Len(t, rows, 30)
+	assertTestRows(t, rows)
+}
+
+func TestRecoverFile_RowGroupRows(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 3, 30, 3)
+	def := testSchemaDefinition(t, file)
+	end := file.pages[2][1][2][1]
+
+	rec, err := RecoverFile(memory.NewReader(file.data[:end]), def, &RecoverOptions{RowGroupRows: 30})
+	require.NoError(t, err)
+
+	require.Len(t, rec.MetaData.RowGroups, 3)
+	assert.False(t, rec.Ambiguous)
+
+	for i, rg := range rec.MetaData.RowGroups {
+		assert.Equal(t, int64(30), rg.NumRows)
+		require.Len(t, rg.Columns, 2)
+		assert.Equal(t, []string{"id"}, rg.Columns[0].MetaData.PathInSchema)
+		assert.Equal(t, []string{"a"}, rg.Columns[1].MetaData.PathInSchema)
+
+		for j, c := range rg.Columns {
+			assert.Equal(t, int64(30), c.MetaData.NumValues)
+			assert.Equal(t, c.FileOffset, c.MetaData.DataPageOffset)
+			assert.Less(t, c.FileOffset, int64(file.pages[i][j][0][0]))
+			assert.Equal(t, int64(file.pages[i][j][2][1]), c.FileOffset+c.MetaData.TotalCompressedSize)
+		}
+	}
+
+	rows := readRecoveredRows(t, rec)
+	require.Len(t, rows, 90)
+	assertTestRows(t, rows)
+}
+
+func TestRecoverFile_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 2, 30, 3)
+	def := testSchemaDefinition(t, file)
+	end := file.pages[1][1][2][1]
+
+	// Keep a part of the footer.
+	rec, err := RecoverFile(memory.NewReader(file.data[:end+10]), def, &RecoverOptions{RowGroupRows: 30})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+
+	n, err := rec.WriteTo(buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	r, err := NewFileReader(memory.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(60), r.NumRows())
+	assert.Equal(t, 2, r.RowGroupCount())
+
+	rows, err := readAllRows(t, buf.Bytes(), nil)
+	require.NoError(t, err)
+	require.Len(t, rows, 60)
+	assertTestRows(t, rows)
+}
+
+func TestRecoverFile_NotParquet(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 1, 30, 3)
+	def := testSchemaDefinition(t, file)
+
+	_, err := RecoverFile(memory.NewReader([]byte("not a parquet file")), def, nil)
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, ErrNotParquet))
+}
+
+// readCounter counts the bytes read from a source with Read.
+type readCounter struct {
+	*memory.Reader
+
+	read int64
+}
+
+func (r *readCounter) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+
+	return n, err
+}
+
+func TestRecoverFile_ManyPages(t *testing.T) {
+	t.Parallel()
+
+	file := writeTestFile(t, lenientTestColumns, 1, 1000, 100)
+	def := testSchemaDefinition(t, file)
+	end := file.pages[0][1][99][1]
+
+	src := &readCounter{Reader: memory.NewReader(file.data[:end])}
+
+	rec, err := RecoverFile(src, def, nil)
+	require.NoError(t, err)
+
+	require.Len(t, rec.MetaData.RowGroups, 1)
+	assert.Equal(t, int64(1000), rec.MetaData.NumRows)
+
+	// Each page is decoded once, whatever the number of candidate column chunks it belongs to.
+	assert.Less(t, src.read, int64(4*end))
+
+	rows := readRecoveredRows(t, rec)
+	require.Len(t, rows, 1000)
+	assertTestRows(t, rows)
+}
@@ -0,0 +1,396 @@
+package schema
+
+import (
+	"math"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/logical"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// TimeUnit is the unit of the TIME and TIMESTAMP columns created with Time and Timestamp.
+type TimeUnit int
+
+const (
+	Millis TimeUnit = iota
+	Micros
+	Nanos
+)
+
+const intervalSize = 12
+
+func (u TimeUnit) timeUnit() *parquet.TimeUnit {
+	switch u {
+	case Micros:
+		return &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}
+	case Nanos:
+		return &parquet.TimeUnit{NANOS: &parquet.NanoSeconds{}}
+	default:
+		return &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}
+	}
+}
+
+// Node is the type of a field added to a Builder: a leaf Type or a group Builder.
+type Node interface {
+	definition(name string, rep parquet.FieldRepetitionType) (*ColumnDefinition, error)
+}
+
+// Type is the physical type and the annotations of a leaf column.
+type Type struct {
+	physical  parquet.Type
+	length    int32
+	logical   *parquet.LogicalType
+	converted *parquet.ConvertedType
+	precision int32
+	scale     int32
+}
+
+func (t Type) definition(name string, rep parquet.FieldRepetitionType) (*ColumnDefinition, error) {
+	elem := &parquet.SchemaElement{
+		Name:           name,
+		Type:           parquet.TypePtr(t.physical),
+		RepetitionType: parquet.FieldRepetitionTypePtr(rep),
+		LogicalType:    t.logical,
+		ConvertedType:  t.converted,
+	}
+
+	if t.physical == parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		length := t.length
+		elem.TypeLength = &length
+	}
+
+	if t.logical != nil {
+		if ct, ok := convertedTypeOf(t.logical); ok {
+			elem.ConvertedType = parquet.ConvertedTypePtr(ct)
+		}
+
+		if t.logical.IsSetDECIMAL() {
+			precision, scale := t.precision, t.scale
+			elem.Precision = &precision
+			elem.Scale = &scale
+		}
+	}
+
+	return &ColumnDefinition{SchemaElement: elem}, nil
+}
+
+// Boolean is a BOOLEAN column.
+func Boolean() Type { return Type{physical: parquet.Type_BOOLEAN} }
+
+// Int32 is an INT32 column.
+func Int32() Type { return Type{physical: parquet.Type_INT32} }
+
+// Int64 is an INT64 column.
+func Int64() Type { return Type{physical: parquet.Type_INT64} }
+
+// Int96 is an INT96 column.
+func Int96() Type { return Type{physical: parquet.Type_INT96} }
+
+// Float is a FLOAT column.
+func Float() Type { return Type{physical: parquet.Type_FLOAT} }
+
+// Double is a DOUBLE column.
+func Double() Type { return Type{physical: parquet.Type_DOUBLE} }
+
+// ByteArray is a BYTE_ARRAY column without annotation.
+func ByteArray() Type { return Type{physical: parquet.Type_BYTE_ARRAY} }
+
+// FixedByteArray is a FIXED_LEN_BYTE_ARRAY column of the given length.
+func FixedByteArray(length int32) Type {
+	return Type{physical: parquet.Type_FIXED_LEN_BYTE_ARRAY, length: length}
+}
+
+// String is a BYTE_ARRAY column annotated with STRING.
+func String() Type {
+	return Type{physical: parquet.Type_BYTE_ARRAY, logical: &parquet.LogicalType{STRING: &parquet.StringType{}}}
+}
+
+// Enum is a BYTE_ARRAY column annotated with ENUM.
+func Enum() Type {
+	return Type{physical: parquet.Type_BYTE_ARRAY, logical: &parquet.LogicalType{ENUM: &parquet.EnumType{}}}
+}
+
+// JSON is a BYTE_ARRAY column annotated with JSON.
+func JSON() Type {
+	return Type{physical: parquet.Type_BYTE_ARRAY, logical: &parquet.LogicalType{JSON: &parquet.JsonType{}}}
+}
+
+// BSON is a BYTE_ARRAY column annotated with BSON.
+func BSON() Type {
+	return Type{physical: parquet.Type_BYTE_ARRAY, logical: &parquet.LogicalType{BSON: &parquet.BsonType{}}}
+}
+
+// UUID is a FIXED_LEN_BYTE_ARRAY(16) column annotated with UUID.
+func UUID() Type {
+	return Type{physical: parquet.Type_FIXED_LEN_BYTE_ARRAY, length: uuidSize, logical: &parquet.LogicalType{UUID: &parquet.UUIDType{}}}
+}
+
+// Date is an INT32 column annotated with DATE.
+func Date() Type {
+	return Type{physical: parquet.Type_INT32, logical: &parquet.LogicalType{DATE: &parquet.DateType{}}}
+}
+
+// Time is a column annotated with TIME, INT32 for Millis and INT64 otherwise.
+func Time(unit TimeUnit, utc bool) Type {
+	physical := parquet.Type_INT64
+	if unit == Millis {
+		physical = parquet.Type_INT32
+	}
+
+	return Type{
+		physical: physical,
+		logical:  &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: utc, Unit: unit.timeUnit()}},
+	}
+}
+
+// Timestamp is an INT64 column annotated with TIMESTAMP.
+func Timestamp(unit TimeUnit, utc bool) Type {
+	return Type{
+		physical: parquet.Type_INT64,
+		logical:  &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: unit.timeUnit()}},
+	}
+}
+
+// Int is a column annotated with INTEGER, INT32 for the bit widths 8, 16 and 32 and INT64 for 64.
+func Int(bitWidth int8, signed bool) Type {
+	physical := parquet.Type_INT32
+	if bitWidth > 32 { //nolint:gomnd // bit width
+		physical = parquet.Type_INT64
+	}
+
+	return Type{
+		physical: physical,
+		logical:  &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: bitWidth, IsSigned: signed}},
+	}
+}
+
+// Decimal is a column annotated with DECIMAL, stored in the smallest of INT32, INT64
+// or FIXED_LEN_BYTE_ARRAY that holds the precision.
+func Decimal(precision, scale int32) Type {
+	t := Type{
+		logical:   &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}},
+		precision: precision,
+		scale:     scale,
+	}
+
+	switch {
+	case precision <= logical.MaxInt32Precision:
+		t.physical = parquet.Type_INT32
+	case precision <= logical.MaxInt64Precision:
+		t.physical = parquet.Type_INT64
+	default:
+		// The smallest n with floor((8n-1) * log10(2)) >= precision.
+		t.physical = parquet.Type_FIXED_LEN_BYTE_ARRAY
+		t.length = int32(math.Ceil((float64(precision)/math.Log10(2) + 1) / 8)) //nolint:gomnd // bits per byte
+	}
+
+	return t
+}
+
+// Interval is a FIXED_LEN_BYTE_ARRAY(12) column with the INTERVAL converted type,
+// which has no logical type equivalent.
+func Interval() Type {
+	return Type{
+		physical:  parquet.Type_FIXED_LEN_BYTE_ARRAY,
+		length:    intervalSize,
+		converted: parquet.ConvertedTypePtr(parquet.ConvertedType_INTERVAL),
+	}
+}
+
+// Builder builds a schema definition, or a group of a schema definition, field by field:
+//
+//	def, err := schema.Message("m").
+//		Required("id", schema.Int64()).
+//		Optional("name", schema.String()).
+//		List("tags", schema.String()).
+//		Map("attrs", schema.String(), schema.Int32()).
+//		Group("address", schema.Group().Required("city", schema.String())).
+//		Build()
+//
+// The first error, like a duplicate field name, is reported by Build.
+type Builder struct {
+	name   string
+	fields []*ColumnDefinition
+	names  map[string]bool
+	err    error
+}
+
+// Message starts the builder of a schema definition with the given root name.
+func Message(name string) *Builder {
+	return &Builder{name: name, names: make(map[string]bool)}
+}
+
+// Group starts the builder of a group field, to be added with Required, Optional,
+// Repeated, List or Map.
+func Group() *Builder {
+	return &Builder{names: make(map[string]bool)}
+}
+
+// Required adds a required field.
+func (b *Builder) Required(name string, n Node) *Builder {
+	return b.add(name, n, parquet.FieldRepetitionType_REQUIRED)
+}
+
+// Optional adds an optional field.
+func (b *Builder) Optional(name string, n Node) *Builder {
+	return b.add(name, n, parquet.FieldRepetitionType_OPTIONAL)
+}
+
+// Repeated adds a repeated field, without LIST annotation.
+func (b *Builder) Repeated(name string, n Node) *Builder {
+	return b.add(name, n, parquet.FieldRepetitionType_REPEATED)
+}
+
+// Group adds an optional group field, like Optional(name, fields).
+func (b *Builder) Group(name string, fields *Builder) *Builder {
+	return b.Optional(name, fields)
+}
+
+// List adds an optional LIST group of optional elements, with the three-level structure:
+//
+//	optional group <name> (LIST) {
+//	  repeated group list {
+//	    optional <element-type> element;
+//	  }
+//	}
+func (b *Builder) List(name string, element Node) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	elem, err := element.definition("element", parquet.FieldRepetitionType_OPTIONAL)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	return b.addDefinition(name, &ColumnDefinition{
+		SchemaElement: &parquet.SchemaElement{
+			Name:           name,
+			RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL),
+			LogicalType:    &parquet.LogicalType{LIST: &parquet.ListType{}},
+			ConvertedType:  parquet.ConvertedTypePtr(parquet.ConvertedType_LIST),
+		},
+		Children: []*ColumnDefinition{{
+			SchemaElement: &parquet.SchemaElement{
+				Name:           "list",
+				RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REPEATED),
+			},
+			Children: []*ColumnDefinition{elem},
+		}},
+	})
+}
+
+// Map adds an optional MAP group of required keys and optional values:
+//
+//	optional group <name> (MAP) {
+//	  repeated group key_value {
+//	    required <key-type> key;
+//	    optional <value-type> value;
+//	  }
+//	}
+func (b *Builder) Map(name string, key, value Node) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	k, err := key.definition("key", parquet.FieldRepetitionType_REQUIRED)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	v, err := value.definition("value", parquet.FieldRepetitionType_OPTIONAL)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	return b.addDefinition(name, &ColumnDefinition{
+		SchemaElement: &parquet.SchemaElement{
+			Name:           name,
+			RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL),
+			LogicalType:    &parquet.LogicalType{MAP: &parquet.MapType{}},
+			ConvertedType:  parquet.ConvertedTypePtr(parquet.ConvertedType_MAP),
+		},
+		Children: []*ColumnDefinition{{
+			SchemaElement: &parquet.SchemaElement{
+				Name:           "key_value",
+				RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REPEATED),
+			},
+			Children: []*ColumnDefinition{k, v},
+		}},
+	})
+}
+
+// Build returns the schema definition, after checking it with Validate.
+func (b *Builder) Build() (*SchemaDefinition, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	def, err := b.definition(b.name, parquet.FieldRepetitionType_REQUIRED)
+	if err != nil {
+		return nil, err
+	}
+
+	sd := def.AsSchemaDefinition()
+	if err := Validate(sd); err != nil {
+		return nil, err
+	}
+
+	return sd, nil
+}
+
+func (b *Builder) definition(name string, rep parquet.FieldRepetitionType) (*ColumnDefinition, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(b.fields) == 0 {
+		return nil, errors.WithFields(
+			errors.New("group without field"),
+			errors.Fields{
+				"name": name,
+			})
+	}
+
+	return &ColumnDefinition{
+		SchemaElement: &parquet.SchemaElement{
+			Name:           name,
+			RepetitionType: parquet.FieldRepetitionTypePtr(rep),
+		},
+		Children: append([]*ColumnDefinition(nil), b.fields...),
+	}, nil
+}
+
+func (b *Builder) add(name string, n Node, rep parquet.FieldRepetitionType) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	def, err := n.definition(name, rep)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	return b.addDefinition(name, def)
+}
+
+func (b *Builder) addDefinition(name string, def *ColumnDefinition) *Builder {
+	if b.names[name] {
+		b.err = errors.WithFields(
+			errors.New("duplicate field name"),
+			errors.Fields{
+				"name": name,
+			})
+
+		return b
+	}
+
+	b.names[name] = true
+	b.fields = append(b.fields, def)
+
+	return b
+}
@@ -0,0 +1,194 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("Message", TestBuilder_Message)
+	t.Run("List", TestBuilder_List)
+	t.Run("Map", TestBuilder_Map)
+	t.Run("Group", TestBuilder_Group)
+	t.Run("Types", TestBuilder_Types)
+	t.Run("Decimal", TestBuilder_Decimal)
+	t.Run("DuplicateName", TestBuilder_DuplicateName)
+	t.Run("EmptyGroup", TestBuilder_EmptyGroup)
+}
+
+func TestBuilder_Message(t *testing.T) {
+	t.Parallel()
+
+	def, err := Message("m").
+		Required("id", Int64()).
+		Optional("name", String()).
+		Build()
+	require.NoError(t, err)
+
+	elements := def.SchemaElements()
+	require.Len(t, elements, 3)
+
+	assert.Equal(t, "m", elements[0].GetName())
+	assert.Equal(t, int32(2), elements[0].GetNumChildren())
+
+	assert.Equal(t, "id", elements[1].GetName())
+	assert.Equal(t, parquet.Type_INT64, elements[1].GetType())
+	assert.Equal(t, parquet.FieldRepetitionType_REQUIRED, elements[1].GetRepetitionType())
+
+	assert.Equal(t, "name", elements[2].GetName())
+	assert.Equal(t, parquet.Type_BYTE_ARRAY, elements[2].GetType())
+	assert.Equal(t, parquet.FieldRepetitionType_OPTIONAL, elements[2].GetRepetitionType())
+	assert.True(t, elements[2].GetLogicalType().IsSetSTRING())
+	assert.Equal(t, parquet.ConvertedType_UTF8, elements[2].GetConvertedType())
+
+	s, err := LoadSchemaStrict(elements)
+	require.NoError(t, err)
+	assert.Len(t, s.Columns(), 2)
+}
+
+func TestBuilder_List(t *testing.T) {
+	t.Parallel()
+
+	def, err := Message("m").List("tags", String()).Build()
+	require.NoError(t, err)
+
+	elements := def.SchemaElements()
+	require.Len(t, elements, 4)
+
+	assert.Equal(t, "tags", elements[1].GetName())
+	assert.Equal(t, parquet.FieldRepetitionType_OPTIONAL, elements[1].GetRepetitionType())
+	assert.True(t, elements[1].GetLogicalType().IsSetLIST())
+	assert.Equal(t, parquet.ConvertedType_LIST, elements[1].GetConvertedType())
+
+	assert.Equal(t, "list", elements[2].GetName())
+	assert.Equal(t, parquet.FieldRepetitionType_REPEATED, elements[2].GetRepetitionType())
+
+	assert.Equal(t, "element", elements[3].GetName())
+	assert.Equal(t, parquet.FieldRepetitionType_OPTIONAL, elements[3].GetRepetitionType())
+
+	s, err := LoadSchema(elements)
+	require.NoError(t, err)
+	assert.NotNil(t, s.GetColumnByName("tags.list.element"))
+}
+
+func TestBuilder_Map(t *testing.T) {
+	t.Parallel()
+
+	def, err := Message("m").Map("attrs", String(), Int32()).Build()
+	require.NoError(t, err)
+
+	elements := def.SchemaElements()
+	require.Len(t, elements, 5)
+
+	assert.True(t, elements[1].GetLogicalType().IsSetMAP())
+	assert.Equal(t, "key_value", elements[2].GetName())
+	assert.Equal(t, parquet.FieldRepetitionType_REPEATED, elements[2].GetRepetitionType())
+	assert.Equal(t, "key", elements[3].GetName())
+	assert.Equal(t, parquet.FieldRepetitionType_REQUIRED, elements[3].GetRepetitionType())
+	assert.Equal(t, "value", elements[4].GetName())
+	assert.Equal(t, parquet.Type_INT32, elements[4].GetType())
+}
+
+func TestBuilder_Group(t *testing.T) {
+	t.Parallel()
+
+	def, err := Message("m").
+		Group("address", Group().Required("city", String()).Optional("zip", Int32())).
+		List("items", Group().Required("sku", String()).Required("qty", Int32())).
+		Build()
+	require.NoError(t, err)
+
+	s, err := LoadSchema(def.SchemaElements())
+	require.NoError(t, err)
+
+	for _, path := range []string{"address.city", "address.zip", "items.list.element.sku", "items.list.element.qty"} {
+		assert.NotNil(t, s.GetColumnByName(path), path)
+	}
+}
+
+func TestBuilder_Types(t *testing.T) {
+	t.Parallel()
+
+	def, err := Message("m").
+		Required("a", Boolean()).
+		Required("b", Float()).
+		Required("c", Double()).
+		Required("d", Int96()).
+		Required("e", FixedByteArray(3)).
+		Required("f", UUID()).
+		Required("g", Date()).
+		Required("h", Time(Millis, true)).
+		Required("i", Time(Nanos, false)).
+		Required("j", Timestamp(Micros, true)).
+		Required("k", Int(16, false)).
+		Required("l", Int(64, true)).
+		Required("m", Interval()).
+		Required("n", Enum()).
+		Required("o", JSON()).
+		Required("p", BSON()).
+		Build()
+	require.NoError(t, err)
+
+	elements := def.SchemaElements()
+
+	assert.Equal(t, int32(16), elements[6].GetTypeLength())
+	assert.Equal(t, parquet.Type_INT32, elements[8].GetType())
+	assert.Equal(t, parquet.ConvertedType_TIME_MILLIS, elements[8].GetConvertedType())
+	assert.Equal(t, parquet.Type_INT64, elements[9].GetType())
+	assert.False(t, elements[9].IsSetConvertedType())
+	assert.Equal(t, parquet.ConvertedType_TIMESTAMP_MICROS, elements[10].GetConvertedType())
+	assert.Equal(t, parquet.ConvertedType_UINT_16, elements[11].GetConvertedType())
+	assert.Equal(t, parquet.Type_INT64, elements[12].GetType())
+	assert.Equal(t, parquet.ConvertedType_INTERVAL, elements[13].GetConvertedType())
+}
+
+func TestBuilder_Decimal(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		precision int32
+		typ       parquet.Type
+		length    int32
+	}{
+		{precision: 9, typ: parquet.Type_INT32},
+		{precision: 18, typ: parquet.Type_INT64},
+		{precision: 19, typ: parquet.Type_FIXED_LEN_BYTE_ARRAY, length: 9},
+		{precision: 38, typ: parquet.Type_FIXED_LEN_BYTE_ARRAY, length: 16},
+	} {
+		def, err := Message("m").Required("amount", Decimal(tt.precision, 2)).Build()
+		require.NoError(t, err)
+
+		elem := def.SchemaElements()[1]
+		assert.Equal(t, tt.typ, elem.GetType())
+		assert.Equal(t, tt.length, elem.GetTypeLength())
+		assert.Equal(t, tt.precision, elem.GetPrecision())
+		assert.Equal(t, int32(2), elem.GetScale())
+		assert.Equal(t, parquet.ConvertedType_DECIMAL, elem.GetConvertedType())
+	}
+
+	_, err := Message("m").Required("amount", Decimal(10, 12)).Build()
+	assert.Error(t, err)
+}
+
+func TestBuilder_DuplicateName(t *testing.T) {
+	t.Parallel()
+
+	_, err := Message("m").Required("id", Int64()).Optional("id", String()).Build()
+	assert.Error(t, err)
+
+	_, err = Message("m").Group("g", Group().Required("a", Int64()).List("a", Int64())).Build()
+	assert.Error(t, err)
+}
+
+func TestBuilder_EmptyGroup(t *testing.T) {
+	t.Parallel()
+
+	_, err := Message("m").Build()
+	assert.Error(t, err)
+
+	_, err = Message("m").Required("id", Int64()).Group("g", Group()).Build()
+	assert.Error(t, err)
+}
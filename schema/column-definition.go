@@ -18,6 +18,8 @@ func (c *ColumnDefinition) AsSchemaDefinition() *SchemaDefinition {
 	}
 }
 
+// CreateColumn creates the column, and the columns of its children, from the definition.
+// The definition isn't validated, see CreateColumnStrict.
 func (c *ColumnDefinition) CreateColumn() (*Column, error) {
 	params := &datastore.ColumnParameters{
 		LogicalType:   c.SchemaElement.LogicalType,
@@ -55,3 +57,13 @@ func (c *ColumnDefinition) CreateColumn() (*Column, error) {
 
 	return col, nil
 }
+
+// CreateColumnStrict is CreateColumn in strict mode: the column and its children must
+// also follow the rules checked by Validate.
+func (c *ColumnDefinition) CreateColumnStrict() (*Column, error) {
+	if err := ValidateColumn(c); err != nil {
+		return nil, err
+	}
+
+	return c.CreateColumn()
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/datastore"
+	"github.com/hexbee-net/parquet/logical"
 	"github.com/hexbee-net/parquet/parquet"
 )
 
@@ -36,6 +37,9 @@ type Column struct {
 	element *parquet.SchemaElement
 
 	params *datastore.ColumnParameters
+
+	// conversion of the values to their logical representation in reading, nil to disable it.
+	conversion *logical.Options
 }
 
 // AsColumnDefinition creates a new column definition from the provided column.
@@ -71,11 +75,22 @@ func (c *Column) MaxRepetitionLevel() uint16 {
 	return c.maxR
 }
 
-// FlatName returns the name of the column and its parents in dotted notation.
+// FlatName returns the name of the column and its parents in dotted notation, with
+// the dots and the backslashes of the names escaped (see FormatPath).
 func (c *Column) FlatName() string {
 	return c.flatName
 }
 
+// Path returns the names of the parents of the column and of the column.
+func (c *Column) Path() []string {
+	return append([]string(nil), c.nameArray...)
+}
+
+func (c *Column) setPath(path []string) {
+	c.nameArray = path
+	c.flatName = FormatPath(path)
+}
+
 // Name returns the column name.
 func (c *Column) Name() string {
 	return c.name
@@ -129,6 +144,21 @@ func (c *Column) SetSkipped(b bool) {
 	c.data.Skipped = b
 }
 
+// skipped tells if the column, or all the columns of the group, are not read.
+func (c *Column) skipped() bool {
+	if c.data != nil {
+		return c.data.Skipped
+	}
+
+	for i := range c.children {
+		if !c.children[i].skipped() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (c *Column) GetData() (interface{}, int32, error) {
 	if c.children != nil {
 		data, maxD, err := c.getNextData()
@@ -158,10 +188,20 @@ func (c *Column) GetData() (interface{}, int32, error) {
 		}
 	}
 
-	return c.data.Get(int32(c.maxD), int32(c.maxR))
+	v, maxD, err := c.data.Get(int32(c.maxD), int32(c.maxR))
+	if err != nil || v == nil || c.conversion == nil {
+		return v, maxD, err
+	}
+
+	v, err = c.convert(v)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return v, maxD, nil
 }
 
-func (c *Column) readGroupSchema(schema []*parquet.SchemaElement, name string, idx int, dLevel, rLevel uint16) (newIndex int, err error) {
+func (c *Column) readGroupSchema(schema []*parquet.SchemaElement, parent []string, idx int, dLevel, rLevel uint16) (newIndex int, err error) {
 	if len(schema) <= idx {
 		return 0, errors.WithFields(
 			errors.New("schema index out of bound"),
@@ -218,13 +258,7 @@ func (c *Column) readGroupSchema(schema []*parquet.SchemaElement, name string, i
 	c.maxD = dLevel
 	c.maxR = rLevel
 
-	if name == "" {
-		name = s.Name
-	} else {
-		name += "." + s.Name
-	}
-
-	c.flatName = name
+	c.setPath(childPath(parent, s.Name))
 	c.name = s.Name
 	c.element = s
 	c.children = make([]*Column, 0, l)
@@ -237,14 +271,14 @@ func (c *Column) readGroupSchema(schema []*parquet.SchemaElement, name string, i
 
 		if schema[idx].Type == nil {
 			// another group
-			idx, err = child.readGroupSchema(schema, name, idx, dLevel, rLevel)
+			idx, err = child.readGroupSchema(schema, c.nameArray, idx, dLevel, rLevel)
 			if err != nil {
 				return 0, err
 			}
 
 			c.children = append(c.children, child)
 		} else {
-			idx, err = child.readColumnSchema(schema, name, idx, dLevel, rLevel)
+			idx, err = child.readColumnSchema(schema, c.nameArray, idx, dLevel, rLevel)
 			if err != nil {
 				return 0, err
 			}
@@ -256,7 +290,7 @@ func (c *Column) readGroupSchema(schema []*parquet.SchemaElement, name string, i
 	return idx, nil
 }
 
-func (c *Column) readColumnSchema(schema []*parquet.SchemaElement, name string, idx int, dLevel, rLevel uint16) (newIndex int, err error) {
+func (c *Column) readColumnSchema(schema []*parquet.SchemaElement, parent []string, idx int, dLevel, rLevel uint16) (newIndex int, err error) {
 	s := schema[idx]
 
 	if s.Name == "" {
@@ -288,12 +322,7 @@ func (c *Column) readColumnSchema(schema []*parquet.SchemaElement, name string,
 	c.maxD = dLevel
 	c.rep = *s.RepetitionType
 	c.name = s.Name
-
-	if name == "" {
-		c.flatName = s.Name
-	} else {
-		c.flatName = name + "." + s.Name
-	}
+	c.setPath(childPath(parent, s.Name))
 
 	c.data, err = datastore.GetValuesStore(s)
 	if err != nil {
@@ -340,6 +369,10 @@ func (c *Column) getNextData() (map[string]interface{}, int32, error) {
 	var maxD int32
 
 	for i := range c.children {
+		if c.children[i].skipped() {
+			continue
+		}
+
 		data, dl, err := c.children[i].GetData()
 		if err != nil {
 			return nil, 0, err
@@ -383,8 +416,18 @@ func (c *Column) getFirstRDLevel() (rLevel, dLevel int32, last bool) {
 		return c.data.GetRDLevelAt(-1)
 	}
 
-	// there should be at lease 1 child,
+	// there should be at lease 1 child, the skipped children have no levels
+	first := -1
+
 	for i := range c.children {
+		if c.children[i].skipped() {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
 		rLevel, dLevel, last = c.children[i].getFirstRDLevel()
 		if last {
 			return rLevel, dLevel, last
@@ -396,6 +439,11 @@ func (c *Column) getFirstRDLevel() (rLevel, dLevel int32, last bool) {
 		}
 	}
 
+	// all the values are null, the repetition level is the same for all the children
+	if first >= 0 {
+		return c.children[first].getFirstRDLevel()
+	}
+
 	return -1, -1, false
 }
 
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumn(t *testing.T) {
+	t.Run("NullGroupEntry", TestColumn_NullGroupEntry)
+}
+
+func TestColumn_NullGroupEntry(t *testing.T) {
+	t.Parallel()
+
+	optional := parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+
+	s, err := LoadSchema([]*parquet.SchemaElement{
+		{Name: "root", RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED), NumChildren: thrift.Int32Ptr(1)},
+		{Name: "items", RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REPEATED), NumChildren: thrift.Int32Ptr(2)},
+		{Name: "a", RepetitionType: optional, Type: parquet.TypePtr(parquet.Type_INT64)},
+		{Name: "b", RepetitionType: optional, Type: parquet.TypePtr(parquet.Type_INT64)},
+	})
+	require.NoError(t, err)
+
+	s.ResetData()
+
+	// The second entry of the first row has only null values.
+	for i, values := range [][]interface{}{{int64(1), int64(3)}, {int64(2), int64(4)}} {
+		store := s.Columns()[i].ColumnStore()
+		store.Values.NoDictMode = true
+		store.Values.Values = values
+
+		for _, l := range [][2]int32{{0, 2}, {1, 1}, {1, 2}, {0, 0}} {
+			store.RepetitionLevels.AppendSingle(l[0])
+			store.DefinitionLevels.AppendSingle(l[1])
+		}
+	}
+
+	d, err := s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"a": int64(1), "b": int64(2)},
+			{},
+			{"a": int64(3), "b": int64(4)},
+		},
+	}, d)
+
+	d, err = s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, d)
+}
@@ -0,0 +1,390 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// ChangeKind is the kind of a difference between two schema definitions.
+type ChangeKind int
+
+const (
+	FieldAdded ChangeKind = iota
+	FieldRemoved
+	TypeChanged
+	RepetitionChanged
+	LogicalTypeChanged
+	FieldIDChanged
+	FieldRenamed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case FieldAdded:
+		return "added"
+	case FieldRemoved:
+		return "removed"
+	case TypeChanged:
+		return "type change"
+	case RepetitionChanged:
+		return "repetition change"
+	case LogicalTypeChanged:
+		return "logical type change"
+	case FieldIDChanged:
+		return "field ID change"
+	case FieldRenamed:
+		return "rename"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Compatibility tells if the data written with one schema can be read with the other.
+// Backward compatibility is reading the data written with the old schema with the new
+// one, forward compatibility is reading the data written with the new schema with the old one.
+type Compatibility int
+
+const (
+	// FullyCompatible changes are backward and forward compatible.
+	FullyCompatible Compatibility = iota
+	BackwardCompatible
+	ForwardCompatible
+	Breaking
+)
+
+func (c Compatibility) String() string {
+	switch c {
+	case FullyCompatible:
+		return "compatible"
+	case BackwardCompatible:
+		return "backward compatible"
+	case ForwardCompatible:
+		return "forward compatible"
+	case Breaking:
+		return "breaking"
+	default:
+		return fmt.Sprintf("Compatibility(%d)", int(c))
+	}
+}
+
+// and returns the compatibility of two changes applied together.
+func (c Compatibility) and(o Compatibility) Compatibility {
+	switch {
+	case c == o || o == FullyCompatible:
+		return c
+	case c == FullyCompatible:
+		return o
+	default:
+		return Breaking
+	}
+}
+
+// Difference is a difference of a column between two schema definitions.
+type Difference struct {
+	// Path is the dotted path of the column, formatted by FormatPath.
+	Path          string
+	Kind          ChangeKind
+	Compatibility Compatibility
+	// From and To describe the column in the old and in the new schema, empty when
+	// the column is added or removed.
+	From string
+	To   string
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case FieldAdded:
+		return fmt.Sprintf("%s: %s %s (%s)", d.Path, d.Kind, d.To, d.Compatibility)
+	case FieldRemoved:
+		return fmt.Sprintf("%s: %s %s (%s)", d.Path, d.Kind, d.From, d.Compatibility)
+	default:
+		return fmt.Sprintf("%s: %s from %s to %s (%s)", d.Path, d.Kind, d.From, d.To, d.Compatibility)
+	}
+}
+
+// Differences are the differences between two schema definitions returned by Compare.
+type Differences []Difference
+
+// Compatibility returns the compatibility of all the differences together.
+func (d Differences) Compatibility() Compatibility {
+	c := FullyCompatible
+	for i := range d {
+		c = c.and(d[i].Compatibility)
+	}
+
+	return c
+}
+
+// Breaking returns the breaking differences.
+func (d Differences) Breaking() Differences {
+	var res Differences
+
+	for i := range d {
+		if d[i].Compatibility == Breaking {
+			res = append(res, d[i])
+		}
+	}
+
+	return res
+}
+
+// Compare returns the differences between the old schema definition a and the new
+// schema definition b. The columns are matched by field ID, then by name, like in Merge,
+// so a column renamed with the same field ID is a FieldRenamed difference, compatible
+// for the readers resolving the columns by field ID. The differences are listed in the
+// depth-first order of a, followed for each group by the columns added in b.
+// The textual schema definitions are compared once loaded with ParseSchemaDefinition.
+func Compare(a, b *SchemaDefinition) Differences {
+	var res Differences
+
+	compareChildren(&res, nil, rootChildren(a), rootChildren(b))
+
+	return res
+}
+
+func rootChildren(def *SchemaDefinition) []*ColumnDefinition {
+	if def == nil || def.RootColumn == nil {
+		return nil
+	}
+
+	return def.RootColumn.Children
+}
+
+func compareChildren(res *Differences, parent []string, a, b []*ColumnDefinition) {
+	matches := matchChildren(a, b)
+	found := make(map[*ColumnDefinition]bool, len(matches))
+
+	for _, ca := range a {
+		path := append(append([]string(nil), parent...), ca.SchemaElement.GetName())
+
+		cb := matches[ca]
+		if cb == nil {
+			*res = append(*res, Difference{
+				Path:          FormatPath(path),
+				Kind:          FieldRemoved,
+				Compatibility: removedCompatibility(ca),
+				From:          describeColumn(ca),
+			})
+
+			continue
+		}
+
+		found[cb] = true
+
+		if from, to := ca.SchemaElement.GetName(), cb.SchemaElement.GetName(); from != to {
+			*res = append(*res, Difference{
+				Path:          FormatPath(path),
+				Kind:          FieldRenamed,
+				Compatibility: FullyCompatible,
+				From:          from,
+				To:            to,
+			})
+		}
+
+		compareColumns(res, path, ca, cb)
+	}
+
+	for _, cb := range b {
+		if found[cb] {
+			continue
+		}
+
+		*res = append(*res, Difference{
+			Path:          FormatPath(childPath(parent, cb.SchemaElement.GetName())),
+			Kind:          FieldAdded,
+			Compatibility: addedCompatibility(cb),
+			To:            describeColumn(cb),
+		})
+	}
+}
+
+// matchChildren matches the columns of a with the ones of b, by field ID first, then
+// by name, like matchColumn. Each column of b is matched at most once.
+func matchChildren(a, b []*ColumnDefinition) map[*ColumnDefinition]*ColumnDefinition {
+	matches := make(map[*ColumnDefinition]*ColumnDefinition, len(a))
+	used := make(map[*ColumnDefinition]bool, len(b))
+
+	match := func(same func(ea, eb *parquet.SchemaElement) bool) {
+		for _, ca := range a {
+			if matches[ca] != nil {
+				continue
+			}
+
+			for _, cb := range b {
+				if !used[cb] && same(ca.SchemaElement, cb.SchemaElement) {
+					matches[ca] = cb
+					used[cb] = true
+
+					break
+				}
+			}
+		}
+	}
+
+	match(func(ea, eb *parquet.SchemaElement) bool {
+		return ea.IsSetFieldID() && eb.IsSetFieldID() && ea.GetFieldID() == eb.GetFieldID()
+	})
+	match(func(ea, eb *parquet.SchemaElement) bool {
+		return ea.GetName() == eb.GetName()
+	})
+
+	return matches
+}
+
+func compareColumns(res *Differences, path []string, a, b *ColumnDefinition) {
+	ea, eb := a.SchemaElement, b.SchemaElement
+	p := FormatPath(path)
+
+	add := func(kind ChangeKind, c Compatibility, from, to string) {
+		*res = append(*res, Difference{Path: p, Kind: kind, Compatibility: c, From: from, To: to})
+	}
+
+	if ra, rb := ea.GetRepetitionType(), eb.GetRepetitionType(); ra != rb {
+		add(RepetitionChanged, repetitionCompatibility(ra, rb), ra.String(), rb.String())
+	}
+
+	if ea.IsSetFieldID() && eb.IsSetFieldID() && ea.GetFieldID() != eb.GetFieldID() {
+		add(FieldIDChanged, Breaking, fmt.Sprint(ea.GetFieldID()), fmt.Sprint(eb.GetFieldID()))
+	}
+
+	groupA, groupB := len(a.Children) > 0, len(b.Children) > 0
+	if groupA != groupB {
+		add(TypeChanged, Breaking, describeType(a), describeType(b))
+		return
+	}
+
+	if !annotationsEqual(ea, eb) {
+		add(LogicalTypeChanged, annotationCompatibility(ea, eb), annotationName(ea), annotationName(eb))
+	}
+
+	if groupA {
+		compareChildren(res, path, a.Children, b.Children)
+		return
+	}
+
+	if ta, tb := describeType(a), describeType(b); ta != tb {
+		add(TypeChanged, typeCompatibility(ea, eb), ta, tb)
+	}
+}
+
+// addedCompatibility is the compatibility of a column added to the new schema: the old
+// readers ignore it, the new readers read nulls in the old data only if it is not required.
+func addedCompatibility(c *ColumnDefinition) Compatibility {
+	if c.SchemaElement.GetRepetitionType() == parquet.FieldRepetitionType_REQUIRED {
+		return ForwardCompatible
+	}
+
+	return FullyCompatible
+}
+
+// removedCompatibility is the compatibility of a column removed from the new schema: the
+// new readers ignore it, the old readers read nulls in the new data only if it is not required.
+func removedCompatibility(c *ColumnDefinition) Compatibility {
+	if c.SchemaElement.GetRepetitionType() == parquet.FieldRepetitionType_REQUIRED {
+		return BackwardCompatible
+	}
+
+	return FullyCompatible
+}
+
+func repetitionCompatibility(a, b parquet.FieldRepetitionType) Compatibility {
+	switch {
+	case a == parquet.FieldRepetitionType_REQUIRED && b == parquet.FieldRepetitionType_OPTIONAL:
+		return BackwardCompatible
+	case a == parquet.FieldRepetitionType_OPTIONAL && b == parquet.FieldRepetitionType_REQUIRED:
+		return ForwardCompatible
+	default:
+		return Breaking
+	}
+}
+
+// typeCompatibility allows the promotions of INT32 to INT64 and of FLOAT to DOUBLE.
+func typeCompatibility(a, b *parquet.SchemaElement) Compatibility {
+	ta, tb := a.GetType(), b.GetType()
+
+	switch {
+	case ta == parquet.Type_INT32 && tb == parquet.Type_INT64, ta == parquet.Type_FLOAT && tb == parquet.Type_DOUBLE:
+		return BackwardCompatible
+	case ta == parquet.Type_INT64 && tb == parquet.Type_INT32, ta == parquet.Type_DOUBLE && tb == parquet.Type_FLOAT:
+		return ForwardCompatible
+	default:
+		return Breaking
+	}
+}
+
+// annotationCompatibility allows the increase of the precision of a decimal with the same scale.
+func annotationCompatibility(a, b *parquet.SchemaElement) Compatibility {
+	la, lb := a.GetLogicalType(), b.GetLogicalType()
+	if la == nil || lb == nil || !la.IsSetDECIMAL() || !lb.IsSetDECIMAL() || la.DECIMAL.Scale != lb.DECIMAL.Scale {
+		return Breaking
+	}
+
+	if la.DECIMAL.Precision < lb.DECIMAL.Precision {
+		return BackwardCompatible
+	}
+
+	return ForwardCompatible
+}
+
+// annotationsEqual compares the logical types of the columns when both have one,
+// and their converted types otherwise.
+func annotationsEqual(a, b *parquet.SchemaElement) bool {
+	la, lb := a.GetLogicalType(), b.GetLogicalType()
+	if la != nil && lb != nil {
+		return logicalTypeName(la) == logicalTypeName(lb)
+	}
+
+	ca, okA := convertedTypeOfElement(a)
+	cb, okB := convertedTypeOfElement(b)
+
+	return okA == okB && ca == cb
+}
+
+// convertedTypeOfElement returns the converted type of elem, or the one of its logical type.
+func convertedTypeOfElement(elem *parquet.SchemaElement) (parquet.ConvertedType, bool) {
+	if elem.IsSetConvertedType() {
+		return elem.GetConvertedType(), true
+	}
+
+	if lt := elem.GetLogicalType(); lt != nil {
+		return convertedTypeOf(lt)
+	}
+
+	return 0, false
+}
+
+func annotationName(elem *parquet.SchemaElement) string {
+	if lt := elem.GetLogicalType(); lt != nil {
+		return logicalTypeName(lt)
+	}
+
+	if elem.IsSetConvertedType() {
+		return elem.GetConvertedType().String()
+	}
+
+	return "none"
+}
+
+func describeType(c *ColumnDefinition) string {
+	if len(c.Children) > 0 {
+		return "group"
+	}
+
+	elem := c.SchemaElement
+	if elem.GetType() == parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		return fmt.Sprintf("%s(%d)", elem.GetType(), elem.GetTypeLength())
+	}
+
+	return elem.GetType().String()
+}
+
+func describeColumn(c *ColumnDefinition) string {
+	desc := strings.ToLower(c.SchemaElement.GetRepetitionType().String()) + " " + describeType(c)
+	if name := annotationName(c.SchemaElement); name != "none" {
+		desc += " " + name
+	}
+
+	return desc
+}
@@ -0,0 +1,203 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("Identical", TestCompare_Identical)
+	t.Run("Added", TestCompare_Added)
+	t.Run("Removed", TestCompare_Removed)
+	t.Run("Repetition", TestCompare_Repetition)
+	t.Run("Type", TestCompare_Type)
+	t.Run("LogicalType", TestCompare_LogicalType)
+	t.Run("FieldID", TestCompare_FieldID)
+	t.Run("Nested", TestCompare_Nested)
+	t.Run("Renamed", TestCompare_Renamed)
+	t.Run("Text", TestCompare_Text)
+}
+
+func build(t *testing.T, b *Builder) *SchemaDefinition {
+	t.Helper()
+
+	def, err := b.Build()
+	require.NoError(t, err)
+
+	return def
+}
+
+func TestCompare_Identical(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("id", Int64()).List("tags", String()).Map("attrs", String(), Int32()))
+
+	// The same schema, loaded from its flattened elements.
+	s, err := LoadSchema(a.SchemaElements())
+	require.NoError(t, err)
+
+	d := Compare(a, s.GetSchemaDefinition())
+	assert.Empty(t, d)
+	assert.Equal(t, FullyCompatible, d.Compatibility())
+}
+
+func TestCompare_Added(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("id", Int64()))
+	b := build(t, Message("m").Required("id", Int64()).Optional("name", String()).Required("age", Int32()))
+
+	d := Compare(a, b)
+	require.Len(t, d, 2)
+
+	assert.Equal(t, Difference{Path: "name", Kind: FieldAdded, Compatibility: FullyCompatible, To: "optional BYTE_ARRAY STRING"}, d[0])
+	assert.Equal(t, Difference{Path: "age", Kind: FieldAdded, Compatibility: ForwardCompatible, To: "required INT32"}, d[1])
+	assert.Equal(t, ForwardCompatible, d.Compatibility())
+	assert.Equal(t, "age: added required INT32 (forward compatible)", d[1].String())
+}
+
+func TestCompare_Removed(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("id", Int64()).Optional("name", String()).Required("age", Int32()))
+	b := build(t, Message("m").Required("id", Int64()))
+
+	d := Compare(a, b)
+	require.Len(t, d, 2)
+
+	assert.Equal(t, FieldRemoved, d[0].Kind)
+	assert.Equal(t, FullyCompatible, d[0].Compatibility)
+	assert.Equal(t, "age", d[1].Path)
+	assert.Equal(t, BackwardCompatible, d[1].Compatibility)
+}
+
+func TestCompare_Repetition(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("a", Int64()).Optional("b", Int64()).Optional("c", Int64()))
+	b := build(t, Message("m").Optional("a", Int64()).Required("b", Int64()).Repeated("c", Int64()))
+
+	d := Compare(a, b)
+	require.Len(t, d, 3)
+
+	assert.Equal(t, RepetitionChanged, d[0].Kind)
+	assert.Equal(t, BackwardCompatible, d[0].Compatibility)
+	assert.Equal(t, ForwardCompatible, d[1].Compatibility)
+	assert.Equal(t, Breaking, d[2].Compatibility)
+	assert.Equal(t, Breaking, d.Compatibility())
+	assert.Len(t, d.Breaking(), 1)
+}
+
+func TestCompare_Type(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("a", Int32()).Required("b", Double()).Required("c", FixedByteArray(4)).
+		Required("d", Int64()))
+	b := build(t, Message("m").Required("a", Int64()).Required("b", Float()).Required("c", FixedByteArray(8)).
+		Required("d", Group().Required("x", Int64())))
+
+	d := Compare(a, b)
+	require.Len(t, d, 4)
+
+	assert.Equal(t, Difference{Path: "a", Kind: TypeChanged, Compatibility: BackwardCompatible, From: "INT32", To: "INT64"}, d[0])
+	assert.Equal(t, ForwardCompatible, d[1].Compatibility)
+	assert.Equal(t, Difference{
+		Path: "c", Kind: TypeChanged, Compatibility: Breaking,
+		From: "FIXED_LEN_BYTE_ARRAY(4)", To: "FIXED_LEN_BYTE_ARRAY(8)",
+	}, d[2])
+	assert.Equal(t, Difference{Path: "d", Kind: TypeChanged, Compatibility: Breaking, From: "INT64", To: "group"}, d[3])
+}
+
+func TestCompare_LogicalType(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("a", Decimal(10, 2)).Required("b", String()).Required("c", Timestamp(Millis, true)))
+	b := build(t, Message("m").Required("a", Decimal(12, 2)).Required("b", Enum()).Required("c", Timestamp(Micros, true)))
+
+	d := Compare(a, b)
+	require.Len(t, d, 3)
+
+	assert.Equal(t, Difference{
+		Path: "a", Kind: LogicalTypeChanged, Compatibility: BackwardCompatible,
+		From: "DECIMAL(10,2)", To: "DECIMAL(12,2)",
+	}, d[0])
+	assert.Equal(t, Breaking, d[1].Compatibility)
+	assert.Equal(t, "TIMESTAMP(MILLIS,true)", d[2].From)
+
+	// A legacy converted type is the same annotation as its logical type.
+	legacy := build(t, Message("m").Required("b", ByteArray()))
+	legacy.RootColumn.Children[0].SchemaElement.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+	assert.Empty(t, Compare(legacy, build(t, Message("m").Required("b", String()))))
+}
+
+func TestCompare_FieldID(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("id", Int64()))
+	b := build(t, Message("m").Required("id", Int64()))
+
+	id1, id2 := int32(1), int32(2)
+	a.RootColumn.Children[0].SchemaElement.FieldID = &id1
+	assert.Empty(t, Compare(a, b))
+
+	b.RootColumn.Children[0].SchemaElement.FieldID = &id2
+	d := Compare(a, b)
+	require.Len(t, d, 1)
+	assert.Equal(t, Difference{Path: "id", Kind: FieldIDChanged, Compatibility: Breaking, From: "1", To: "2"}, d[0])
+}
+
+func TestCompare_Nested(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").List("items", Group().Required("sku", String())))
+	b := build(t, Message("m").List("items", Group().Required("sku", String()).Optional("qty", Int32())))
+
+	d := Compare(a, b)
+	require.Len(t, d, 1)
+	assert.Equal(t, "items.list.element.qty", d[0].Path)
+	assert.Equal(t, FieldAdded, d[0].Kind)
+}
+
+func TestCompare_Renamed(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("id", Int64()).Optional("name", String()))
+	b := build(t, Message("m").Required("id", Int64()).Optional("label", String()).Optional("name", Int32()))
+
+	id := int32(7)
+	a.RootColumn.Children[1].SchemaElement.FieldID = &id
+	b.RootColumn.Children[1].SchemaElement.FieldID = &id
+
+	d := Compare(a, b)
+	require.Len(t, d, 2)
+	assert.Equal(t, Difference{Path: "name", Kind: FieldRenamed, Compatibility: FullyCompatible, From: "name", To: "label"}, d[0])
+	assert.Equal(t, Difference{Path: "name", Kind: FieldAdded, Compatibility: FullyCompatible, To: "optional INT32"}, d[1])
+	assert.Equal(t, "name: rename from name to label (compatible)", d[0].String())
+}
+
+func TestCompare_Text(t *testing.T) {
+	t.Parallel()
+
+	a, err := ParseSchemaDefinition(`message m {
+		required int32 id = 1;
+		optional binary name (STRING) = 2;
+	}`)
+	require.NoError(t, err)
+
+	b, err := ParseSchemaDefinition(`message m {
+		required int64 id = 1;
+		optional binary full_name (STRING) = 2;
+	}`)
+	require.NoError(t, err)
+
+	d := Compare(a, b)
+	require.Len(t, d, 2)
+	assert.Equal(t, Difference{Path: "id", Kind: TypeChanged, Compatibility: BackwardCompatible, From: "INT32", To: "INT64"}, d[0])
+	assert.Equal(t, Difference{Path: "name", Kind: FieldRenamed, Compatibility: FullyCompatible, From: "name", To: "full_name"}, d[1])
+
+	// The parsed definition is the one of the same schema built in code.
+	assert.Empty(t, Compare(a, build(t, Message("m").Required("id", Int32()).Optional("name", String()))))
+}
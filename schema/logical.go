@@ -0,0 +1,263 @@
+package schema
+
+import (
+	"reflect"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/logical"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// SetLogicalAssembly enables or disables the logical assembly of the data returned by GetData.
+//
+// Without it, the data follows the physical structure of the schema. With it, the groups
+// annotated as LIST are returned as []interface{}, the groups annotated as MAP or
+// MAP_KEY_VALUE as map[interface{}]interface{}, and the repeated fields outside of
+// them as []interface{}. The backward-compatibility rules of the parquet format for
+// the lists written with two levels are applied:
+//   - a repeated primitive field is the element of the list,
+//   - a repeated group with several fields is the element of the list,
+//   - a repeated group with one field named "array" or "<list-name>_tuple" is the element of the list,
+//   - otherwise, the only field of the repeated group is the element of the list.
+//
+// The BYTE_ARRAY map keys are converted to strings.
+func (s *Schema) SetLogicalAssembly(enabled bool) {
+	s.logical = enabled
+}
+
+// SetLogicalConversion enables the conversion of the values of the columns annotated with a
+// temporal or decimal logical type by GetData, as done by logical.ToLogical, nil disables it.
+// The repeated values of the converted columns are returned as []interface{}.
+func (s *Schema) SetLogicalConversion(opts *logical.Options) {
+	for _, col := range s.Columns() {
+		col.conversion = nil
+
+		if opts != nil && logical.HasConversion(col.Element()) {
+			col.conversion = opts
+		}
+	}
+}
+
+// convert returns the logical value of v, the data of the column c.
+func (c *Column) convert(v interface{}) (interface{}, error) {
+	if c.rep != parquet.FieldRepetitionType_REPEATED {
+		return logical.ToLogical(c.Element(), v, c.conversion)
+	}
+
+	items := toSlice(v)
+	for i := range items {
+		value, err := logical.ToLogical(c.Element(), items[i], c.conversion)
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = value
+	}
+
+	return items, nil
+}
+
+func isListAnnotated(e *parquet.SchemaElement) bool {
+	lt := e.GetLogicalType()
+
+	return lt != nil && lt.IsSetLIST() ||
+		e.IsSetConvertedType() && e.GetConvertedType() == parquet.ConvertedType_LIST
+}
+
+func isMapAnnotated(e *parquet.SchemaElement) bool {
+	lt := e.GetLogicalType()
+
+	return lt != nil && lt.IsSetMAP() ||
+		e.IsSetConvertedType() && (e.GetConvertedType() == parquet.ConvertedType_MAP ||
+			e.GetConvertedType() == parquet.ConvertedType_MAP_KEY_VALUE)
+}
+
+// repeatedChild returns the only child of the group c if it is repeated, nil otherwise.
+func (c *Column) repeatedChild() *Column {
+	if len(c.children) != 1 || c.children[0].rep != parquet.FieldRepetitionType_REPEATED {
+		return nil
+	}
+
+	return c.children[0]
+}
+
+// listElement returns the element of the LIST group c, whose repeated field is r,
+// and whether the element is wrapped in r.
+func (c *Column) listElement(r *Column) (elem *Column, wrapped bool) {
+	if r.data != nil || len(r.children) > 1 || r.name == "array" || r.name == c.name+"_tuple" {
+		return r, false
+	}
+
+	return r.children[0], true
+}
+
+// assembleField returns the logical value of v, the data of the field c of a group.
+func (c *Column) assembleField(v interface{}) (interface{}, error) {
+	if c.rep != parquet.FieldRepetitionType_REPEATED {
+		return c.assembleValue(v)
+	}
+
+	// A repeated field that isn't part of a LIST or a MAP is a list.
+	items := toSlice(v)
+	ret := make([]interface{}, 0, len(items))
+
+	for _, item := range items {
+		value, err := c.assembleValue(item)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, value)
+	}
+
+	return ret, nil
+}
+
+// assembleValue returns the logical value of v, a single value of c.
+func (c *Column) assembleValue(v interface{}) (interface{}, error) {
+	if v == nil || c.data != nil {
+		return v, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.WithFields(
+			errors.New("unexpected group data"),
+			errors.Fields{
+				"column": c.flatName,
+			})
+	}
+
+	if r := c.repeatedChild(); r != nil {
+		switch {
+		case isListAnnotated(c.Element()):
+			return c.assembleList(r, m)
+		case isMapAnnotated(c.Element()) && r.data == nil && len(r.children) > 0:
+			return c.assembleMap(r, m)
+		}
+	}
+
+	return c.assembleGroup(m)
+}
+
+func (c *Column) assembleGroup(m map[string]interface{}) (interface{}, error) {
+	ret := make(map[string]interface{}, len(m))
+
+	for _, child := range c.children {
+		v, ok := m[child.name]
+		if !ok {
+			continue
+		}
+
+		value, err := child.assembleField(v)
+		if err != nil {
+			return nil, err
+		}
+
+		ret[child.name] = value
+	}
+
+	return ret, nil
+}
+
+func (c *Column) assembleList(r *Column, m map[string]interface{}) (interface{}, error) {
+	elem, wrapped := c.listElement(r)
+	items := toSlice(m[r.name])
+	ret := make([]interface{}, 0, len(items))
+
+	for _, item := range items {
+		var (
+			value interface{}
+			err   error
+		)
+
+		if wrapped {
+			value, err = elem.assembleField(toMap(item)[elem.name])
+		} else {
+			value, err = elem.assembleValue(item)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, value)
+	}
+
+	return ret, nil
+}
+
+func (c *Column) assembleMap(kv *Column, m map[string]interface{}) (interface{}, error) {
+	key := kv.children[0]
+	items := toSlice(m[kv.name])
+	ret := make(map[interface{}]interface{}, len(items))
+
+	for _, item := range items {
+		entry := toMap(item)
+
+		k, err := key.assembleField(entry[key.name])
+		if err != nil {
+			return nil, err
+		}
+
+		if b, ok := k.([]byte); ok {
+			k = string(b)
+		}
+
+		if k != nil && !reflect.TypeOf(k).Comparable() {
+			return nil, errors.WithFields(
+				errors.New("map key is not comparable"),
+				errors.Fields{
+					"column": key.flatName,
+				})
+		}
+
+		var value interface{}
+
+		if len(kv.children) > 1 {
+			value, err = kv.children[1].assembleField(entry[kv.children[1].name])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ret[k] = value
+	}
+
+	return ret, nil
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+
+	return m
+}
+
+// toSlice converts the data of a repeated field, a slice of any type, to []interface{}.
+func toSlice(v interface{}) []interface{} {
+	switch s := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return s
+	case []map[string]interface{}:
+		ret := make([]interface{}, len(s))
+		for i := range s {
+			ret[i] = s[i]
+		}
+
+		return ret
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return []interface{}{v}
+	}
+
+	ret := make([]interface{}, rv.Len())
+	for i := range ret {
+		ret[i] = rv.Index(i).Interface()
+	}
+
+	return ret
+}
@@ -0,0 +1,248 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/hexbee-net/parquet/logical"
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// level is a value of a column with its repetition and definition levels, nil for the null values.
+type level struct {
+	r, d  int32
+	value interface{}
+}
+
+func group(name string, rep parquet.FieldRepetitionType, children int32) *parquet.SchemaElement {
+	return &parquet.SchemaElement{
+		Name:           name,
+		RepetitionType: parquet.FieldRepetitionTypePtr(rep),
+		NumChildren:    thrift.Int32Ptr(children),
+	}
+}
+
+func leaf(name string, rep parquet.FieldRepetitionType, typ parquet.Type) *parquet.SchemaElement {
+	return &parquet.SchemaElement{
+		Name:           name,
+		RepetitionType: parquet.FieldRepetitionTypePtr(rep),
+		Type:           parquet.TypePtr(typ),
+	}
+}
+
+func annotated(e *parquet.SchemaElement, logical *parquet.LogicalType, converted *parquet.ConvertedType) *parquet.SchemaElement {
+	e.LogicalType = logical
+	e.ConvertedType = converted
+
+	return e
+}
+
+// loadTestSchema loads the schema elements and fills its columns with the levels.
+func loadTestSchema(t *testing.T, elements []*parquet.SchemaElement, levels map[string][]level) *Schema {
+	t.Helper()
+
+	s, err := LoadSchema(elements)
+	require.NoError(t, err)
+
+	s.ResetData()
+
+	for _, col := range s.Columns() {
+		values, ok := levels[col.FlatName()]
+		require.True(t, ok, col.FlatName())
+
+		store := col.ColumnStore()
+		store.Values.NoDictMode = true
+
+		for _, l := range values {
+			store.RepetitionLevels.AppendSingle(l.r)
+			store.DefinitionLevels.AppendSingle(l.d)
+
+			if l.value != nil {
+				store.Values.Values = append(store.Values.Values, l.value)
+			}
+		}
+	}
+
+	return s
+}
+
+func TestSchema_LogicalAssembly(t *testing.T) {
+	t.Run("List", TestSchema_LogicalAssembly_List)
+	t.Run("TwoLevelList", TestSchema_LogicalAssembly_TwoLevelList)
+	t.Run("Map", TestSchema_LogicalAssembly_Map)
+	t.Run("Repeated", TestSchema_LogicalAssembly_Repeated)
+	t.Run("Disabled", TestSchema_LogicalAssembly_Disabled)
+	t.Run("Conversion", TestSchema_LogicalAssembly_Conversion)
+}
+
+func listSchema() []*parquet.SchemaElement {
+	return []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 1),
+		annotated(group("tags", parquet.FieldRepetitionType_OPTIONAL, 1), &parquet.LogicalType{LIST: parquet.NewListType()}, nil),
+		group("list", parquet.FieldRepetitionType_REPEATED, 1),
+		annotated(leaf("element", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_BYTE_ARRAY), nil, parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)),
+	}
+}
+
+func listLevels() map[string][]level {
+	return map[string][]level{
+		"tags.list.element": {
+			{r: 0, d: 3, value: []byte("a")},
+			{r: 1, d: 2},
+			{r: 0, d: 1},
+			{r: 0, d: 0},
+		},
+	}
+}
+
+func TestSchema_LogicalAssembly_List(t *testing.T) {
+	t.Parallel()
+
+	s := loadTestSchema(t, listSchema(), listLevels())
+	s.SetLogicalAssembly(true)
+
+	expected := []map[string]interface{}{
+		{"tags": []interface{}{[]byte("a"), nil}},
+		{"tags": []interface{}{}},
+		{},
+	}
+
+	for _, e := range expected {
+		d, err := s.GetData()
+		require.NoError(t, err)
+		assert.Equal(t, e, d)
+	}
+}
+
+func TestSchema_LogicalAssembly_TwoLevelList(t *testing.T) {
+	t.Parallel()
+
+	list := parquet.ConvertedTypePtr(parquet.ConvertedType_LIST)
+	s := loadTestSchema(t, []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 3),
+		annotated(group("ints", parquet.FieldRepetitionType_OPTIONAL, 1), nil, list),
+		leaf("ints", parquet.FieldRepetitionType_REPEATED, parquet.Type_INT32),
+		annotated(group("arrays", parquet.FieldRepetitionType_OPTIONAL, 1), nil, list),
+		group("array", parquet.FieldRepetitionType_REPEATED, 1),
+		leaf("x", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+		annotated(group("tuples", parquet.FieldRepetitionType_OPTIONAL, 1), nil, list),
+		group("tuples_tuple", parquet.FieldRepetitionType_REPEATED, 1),
+		leaf("y", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+	}, map[string][]level{
+		"ints.ints":             {{r: 0, d: 2, value: int32(1)}, {r: 1, d: 2, value: int32(2)}},
+		"arrays.array.x":        {{r: 0, d: 2, value: int32(3)}, {r: 1, d: 2, value: int32(4)}},
+		"tuples.tuples_tuple.y": {{r: 0, d: 2, value: int32(5)}},
+	})
+	s.SetLogicalAssembly(true)
+
+	d, err := s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"ints":   []interface{}{int32(1), int32(2)},
+		"arrays": []interface{}{map[string]interface{}{"x": int32(3)}, map[string]interface{}{"x": int32(4)}},
+		"tuples": []interface{}{map[string]interface{}{"y": int32(5)}},
+	}, d)
+}
+
+func TestSchema_LogicalAssembly_Map(t *testing.T) {
+	t.Parallel()
+
+	s := loadTestSchema(t, []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 2),
+		annotated(group("attrs", parquet.FieldRepetitionType_OPTIONAL, 1), &parquet.LogicalType{MAP: parquet.NewMapType()}, nil),
+		group("key_value", parquet.FieldRepetitionType_REPEATED, 2),
+		leaf("key", parquet.FieldRepetitionType_REQUIRED, parquet.Type_BYTE_ARRAY),
+		leaf("value", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_INT64),
+		annotated(group("legacy", parquet.FieldRepetitionType_OPTIONAL, 1), nil, parquet.ConvertedTypePtr(parquet.ConvertedType_MAP_KEY_VALUE)),
+		group("map", parquet.FieldRepetitionType_REPEATED, 1),
+		leaf("key", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+	}, map[string][]level{
+		"attrs.key_value.key":   {{r: 0, d: 2, value: []byte("k")}, {r: 1, d: 2, value: []byte("n")}, {r: 0, d: 1}},
+		"attrs.key_value.value": {{r: 0, d: 3, value: int64(10)}, {r: 1, d: 2}, {r: 0, d: 1}},
+		"legacy.map.key":        {{r: 0, d: 2, value: int32(1)}, {r: 0, d: 0}},
+	})
+	s.SetLogicalAssembly(true)
+
+	d, err := s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"attrs":  map[interface{}]interface{}{"k": int64(10), "n": nil},
+		"legacy": map[interface{}]interface{}{int32(1): nil},
+	}, d)
+
+	d, err = s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"attrs": map[interface{}]interface{}{},
+	}, d)
+}
+
+func TestSchema_LogicalAssembly_Repeated(t *testing.T) {
+	t.Parallel()
+
+	s := loadTestSchema(t, []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 2),
+		leaf("plain", parquet.FieldRepetitionType_REPEATED, parquet.Type_INT64),
+		group("items", parquet.FieldRepetitionType_REPEATED, 1),
+		leaf("id", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT64),
+	}, map[string][]level{
+		"plain":    {{r: 0, d: 1, value: int64(7)}, {r: 1, d: 1, value: int64(8)}},
+		"items.id": {{r: 0, d: 1, value: int64(1)}},
+	})
+	s.SetLogicalAssembly(true)
+
+	d, err := s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"plain": []interface{}{int64(7), int64(8)},
+		"items": []interface{}{map[string]interface{}{"id": int64(1)}},
+	}, d)
+}
+
+func TestSchema_LogicalAssembly_Disabled(t *testing.T) {
+	t.Parallel()
+
+	s := loadTestSchema(t, listSchema(), listLevels())
+
+	d, err := s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"list": []map[string]interface{}{{"element": []byte("a")}, {}},
+		},
+	}, d)
+}
+
+func TestSchema_LogicalAssembly_Conversion(t *testing.T) {
+	t.Parallel()
+
+	date := parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)
+	s := loadTestSchema(t, []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 3),
+		annotated(leaf("day", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_INT32), nil, date),
+		annotated(leaf("days", parquet.FieldRepetitionType_REPEATED, parquet.Type_INT32), nil, date),
+		leaf("n", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+	}, map[string][]level{
+		"day":  {{r: 0, d: 1, value: int32(1)}, {r: 0, d: 0}},
+		"days": {{r: 0, d: 1, value: int32(2)}, {r: 1, d: 1, value: int32(3)}, {r: 0, d: 0}},
+		"n":    {{r: 0, d: 0, value: int32(4)}, {r: 0, d: 0, value: int32(5)}},
+	})
+	s.SetLogicalConversion(&logical.Options{})
+
+	d, err := s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"day":  time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC),
+		"days": []interface{}{time.Date(1970, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(1970, 1, 4, 0, 0, 0, 0, time.UTC)},
+		"n":    int32(4),
+	}, d)
+
+	s.SetLogicalConversion(nil)
+
+	d, err = s.GetData()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"n": int32(5)}, d)
+}
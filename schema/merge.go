@@ -0,0 +1,270 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// MergeConflict is a column that can't be merged.
+type MergeConflict struct {
+	// Path is the dotted path of the column in the merged schema, formatted by FormatPath.
+	Path string
+	// Message describes the conflict.
+	Message string
+}
+
+func (e *MergeConflict) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// MergeConflicts are the conflicts found by Merge.
+type MergeConflicts []*MergeConflict
+
+func (e MergeConflicts) Error() string {
+	msg := make([]string, len(e))
+	for i := range e {
+		msg[i] = e[i].Error()
+	}
+
+	return strings.Join(msg, "; ")
+}
+
+// Merge builds the union of schema definitions, like the schemas of the files of a
+// directory written by different producers. The columns are matched by field ID when
+// both have one and by name otherwise, and merged with the following rules:
+//   - a column missing from some definitions, or required in some and optional in the
+//     others, is optional;
+//   - INT32 and INT64 are widened to INT64, FLOAT and DOUBLE to DOUBLE, the INTEGER
+//     annotations to the largest bit width, and the DECIMAL annotations with the same
+//     scale to the largest precision;
+//   - the other differences are conflicts.
+//
+// The conflicting columns keep their first definition, and the conflicts are returned
+// as MergeConflicts with the merged definition.
+func Merge(defs ...*SchemaDefinition) (*SchemaDefinition, error) {
+	var root *ColumnDefinition
+
+	m := &merger{}
+
+	for _, def := range defs {
+		if def == nil || def.RootColumn == nil {
+			continue
+		}
+
+		if root == nil {
+			root = cloneColumn(def.RootColumn)
+			continue
+		}
+
+		m.children(root, def.RootColumn.Children, nil)
+	}
+
+	if root == nil {
+		return nil, MergeConflicts{{Message: "no schema definition to merge"}}
+	}
+
+	if len(m.conflicts) > 0 {
+		return root.AsSchemaDefinition(), m.conflicts
+	}
+
+	return root.AsSchemaDefinition(), nil
+}
+
+type merger struct {
+	conflicts MergeConflicts
+}
+
+func (m *merger) conflict(path []string, format string, args ...interface{}) {
+	m.conflicts = append(m.conflicts, &MergeConflict{
+		Path:    FormatPath(path),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// children merges the src columns into the children of the dst group.
+func (m *merger) children(dst *ColumnDefinition, src []*ColumnDefinition, parent []string) {
+	matched := make(map[*ColumnDefinition]bool, len(dst.Children))
+
+	for _, s := range src {
+		d := matchColumn(dst.Children, s.SchemaElement)
+		if d == nil {
+			c := cloneColumn(s)
+			relax(c)
+			dst.Children = append(dst.Children, c)
+			matched[c] = true
+
+			continue
+		}
+
+		matched[d] = true
+		m.column(d, s, append(append([]string(nil), parent...), d.SchemaElement.GetName()))
+	}
+
+	for _, d := range dst.Children {
+		if !matched[d] {
+			relax(d)
+		}
+	}
+}
+
+// matchColumn returns the column of children with the field ID of elem, or with its name.
+func matchColumn(children []*ColumnDefinition, elem *parquet.SchemaElement) *ColumnDefinition {
+	if elem.IsSetFieldID() {
+		for _, c := range children {
+			if c.SchemaElement.IsSetFieldID() && c.SchemaElement.GetFieldID() == elem.GetFieldID() {
+				return c
+			}
+		}
+	}
+
+	for _, c := range children {
+		if c.SchemaElement.GetName() == elem.GetName() {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (m *merger) column(dst, src *ColumnDefinition, path []string) {
+	de, se := dst.SchemaElement, src.SchemaElement
+
+	if de.IsSetFieldID() && se.IsSetFieldID() && de.GetFieldID() != se.GetFieldID() {
+		m.conflict(path, "field ID %d doesn't match field ID %d", de.GetFieldID(), se.GetFieldID())
+		return
+	}
+
+	if !de.IsSetFieldID() && se.IsSetFieldID() {
+		id := se.GetFieldID()
+		de.FieldID = &id
+	}
+
+	if dr, sr := de.GetRepetitionType(), se.GetRepetitionType(); dr != sr {
+		if dr == parquet.FieldRepetitionType_REPEATED || sr == parquet.FieldRepetitionType_REPEATED {
+			m.conflict(path, "repetition %s doesn't match repetition %s", dr, sr)
+			return
+		}
+
+		de.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+	}
+
+	groupD, groupS := len(dst.Children) > 0, len(src.Children) > 0
+
+	switch {
+	case groupD != groupS:
+		m.conflict(path, "type %s doesn't match type %s", describeType(dst), describeType(src))
+	case !groupD:
+		m.leaf(de, se, path)
+	case !annotationsEqual(de, se):
+		m.conflict(path, "logical type %s doesn't match logical type %s", annotationName(de), annotationName(se))
+	default:
+		m.children(dst, src.Children, path)
+	}
+}
+
+func (m *merger) leaf(dst, src *parquet.SchemaElement, path []string) {
+	if describeType(&ColumnDefinition{SchemaElement: dst}) == describeType(&ColumnDefinition{SchemaElement: src}) &&
+		annotationsEqual(dst, src) {
+		return
+	}
+
+	merged, ok := widen(dst, src)
+	if !ok {
+		m.conflict(path, "%s can't be merged with %s",
+			describeColumn(&ColumnDefinition{SchemaElement: dst}), describeColumn(&ColumnDefinition{SchemaElement: src}))
+
+		return
+	}
+
+	merged.Name = dst.Name
+	merged.RepetitionType = dst.RepetitionType
+	merged.FieldID = dst.FieldID
+	*dst = *merged
+}
+
+// widen returns the element holding the values of both a and b, if there is one.
+func widen(a, b *parquet.SchemaElement) (*parquet.SchemaElement, bool) {
+	la, lb := a.GetLogicalType(), b.GetLogicalType()
+	_, convA := convertedTypeOfElement(a)
+	_, convB := convertedTypeOfElement(b)
+
+	switch {
+	case !convA && !convB && la == nil && lb == nil:
+		switch t := widerType(a.GetType(), b.GetType()); t {
+		case a.GetType():
+			return a, true
+		case b.GetType():
+			return b, true
+		}
+	case la != nil && lb != nil && la.IsSetINTEGER() && lb.IsSetINTEGER() && la.INTEGER.IsSigned == lb.INTEGER.IsSigned:
+		if la.INTEGER.BitWidth >= lb.INTEGER.BitWidth {
+			return a, true
+		}
+
+		return b, true
+	case la != nil && lb != nil && la.IsSetDECIMAL() && lb.IsSetDECIMAL() && la.DECIMAL.Scale == lb.DECIMAL.Scale:
+		return widenDecimal(a, b)
+	}
+
+	return nil, false
+}
+
+// widerType returns the type holding the values of a and b, -1 if there is none.
+func widerType(a, b parquet.Type) parquet.Type {
+	switch {
+	case a == b:
+		return a
+	case a == parquet.Type_INT32 && b == parquet.Type_INT64, a == parquet.Type_INT64 && b == parquet.Type_INT32:
+		return parquet.Type_INT64
+	case a == parquet.Type_FLOAT && b == parquet.Type_DOUBLE, a == parquet.Type_DOUBLE && b == parquet.Type_FLOAT:
+		return parquet.Type_DOUBLE
+	default:
+		return -1
+	}
+}
+
+// widenDecimal merges two decimals of the same scale stored in INT32 or INT64, or in
+// the same fixed or variable size byte array.
+func widenDecimal(a, b *parquet.SchemaElement) (*parquet.SchemaElement, bool) {
+	t := widerType(a.GetType(), b.GetType())
+	if t != parquet.Type_INT32 && t != parquet.Type_INT64 && (t < 0 || a.GetTypeLength() != b.GetTypeLength()) {
+		return nil, false
+	}
+
+	precision := a.GetLogicalType().DECIMAL.Precision
+	if p := b.GetLogicalType().DECIMAL.Precision; p > precision {
+		precision = p
+	}
+
+	scale := a.GetLogicalType().DECIMAL.Scale
+
+	merged := *a
+	merged.Type = parquet.TypePtr(t)
+	merged.LogicalType = &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}
+	merged.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL)
+	merged.Precision = &precision
+	merged.Scale = &scale
+
+	return &merged, true
+}
+
+// relax makes a column missing from some definitions optional.
+func relax(c *ColumnDefinition) {
+	if c.SchemaElement.GetRepetitionType() == parquet.FieldRepetitionType_REQUIRED {
+		c.SchemaElement.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+	}
+}
+
+// cloneColumn copies the column definition and the schema elements of its tree.
+func cloneColumn(c *ColumnDefinition) *ColumnDefinition {
+	elem := *c.SchemaElement
+	res := &ColumnDefinition{SchemaElement: &elem}
+
+	for _, child := range c.Children {
+		res.Children = append(res.Children, cloneColumn(child))
+	}
+
+	return res
+}
@@ -0,0 +1,187 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("Union", TestMerge_Union)
+	t.Run("Relax", TestMerge_Relax)
+	t.Run("Widen", TestMerge_Widen)
+	t.Run("Decimal", TestMerge_Decimal)
+	t.Run("FieldID", TestMerge_FieldID)
+	t.Run("Nested", TestMerge_Nested)
+	t.Run("Conflicts", TestMerge_Conflicts)
+	t.Run("Empty", TestMerge_Empty)
+}
+
+func withFieldID(def *SchemaDefinition, name string, id int32) *SchemaDefinition {
+	for _, c := range def.RootColumn.Children {
+		if c.SchemaElement.GetName() == name {
+			c.SchemaElement.FieldID = &id
+		}
+	}
+
+	return def
+}
+
+func TestMerge_Union(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Optional("id", Int64()).Optional("name", String()))
+	b := build(t, Message("m").Optional("id", Int64()).Optional("email", String()))
+
+	merged, err := Merge(a, b)
+	require.NoError(t, err)
+
+	expected := build(t, Message("m").Optional("id", Int64()).Optional("name", String()).Optional("email", String()))
+	assert.Empty(t, Compare(expected, merged))
+	assert.NoError(t, Validate(merged))
+
+	// The inputs are not modified.
+	assert.Len(t, a.RootColumn.Children, 2)
+}
+
+func TestMerge_Relax(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("id", Int64()).Required("name", String()).Required("age", Int32()))
+	b := build(t, Message("m").Required("id", Int64()).Optional("name", String()).Required("email", String()))
+
+	merged, err := Merge(a, b)
+	require.NoError(t, err)
+
+	elements := merged.SchemaElements()
+	require.Len(t, elements, 5)
+
+	for _, tt := range []struct {
+		name string
+		rep  parquet.FieldRepetitionType
+	}{
+		{name: "id", rep: parquet.FieldRepetitionType_REQUIRED},
+		{name: "name", rep: parquet.FieldRepetitionType_OPTIONAL},
+		{name: "age", rep: parquet.FieldRepetitionType_OPTIONAL},
+		{name: "email", rep: parquet.FieldRepetitionType_OPTIONAL},
+	} {
+		c := childByName(merged.RootColumn.Children, tt.name)
+		require.NotNil(t, c, tt.name)
+		assert.Equal(t, tt.rep, c.SchemaElement.GetRepetitionType(), tt.name)
+	}
+
+	assert.Equal(t, parquet.FieldRepetitionType_REQUIRED, a.RootColumn.Children[2].SchemaElement.GetRepetitionType())
+}
+
+func TestMerge_Widen(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("a", Int32()).Required("b", Double()).Required("c", Int(8, true)))
+	b := build(t, Message("m").Required("a", Int64()).Required("b", Float()).Required("c", Int(32, true)))
+	c := build(t, Message("m").Required("a", Int32()).Required("b", Float()).Required("c", Int(16, true)))
+
+	merged, err := Merge(a, b, c)
+	require.NoError(t, err)
+
+	expected := build(t, Message("m").Required("a", Int64()).Required("b", Double()).Required("c", Int(32, true)))
+	assert.Empty(t, Compare(expected, merged))
+}
+
+func TestMerge_Decimal(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("amount", Decimal(8, 2)))
+	b := build(t, Message("m").Required("amount", Decimal(12, 2)))
+
+	merged, err := Merge(a, b)
+	require.NoError(t, err)
+
+	elem := merged.RootColumn.Children[0].SchemaElement
+	assert.Equal(t, parquet.Type_INT64, elem.GetType())
+	assert.Equal(t, int32(12), elem.GetLogicalType().DECIMAL.Precision)
+	assert.Equal(t, int32(12), elem.GetPrecision())
+	assert.NoError(t, Validate(merged))
+
+	_, err = Merge(a, build(t, Message("m").Required("amount", Decimal(12, 3))))
+	assert.Error(t, err)
+}
+
+func TestMerge_FieldID(t *testing.T) {
+	t.Parallel()
+
+	a := withFieldID(build(t, Message("m").Required("id", Int64()).Optional("name", String())), "name", 2)
+	b := withFieldID(build(t, Message("m").Required("id", Int64()).Optional("full_name", String())), "full_name", 2)
+
+	merged, err := Merge(a, b)
+	require.NoError(t, err)
+	require.Len(t, merged.RootColumn.Children, 2)
+	assert.Equal(t, "name", merged.RootColumn.Children[1].SchemaElement.GetName())
+
+	// The same name with another field ID is a conflict.
+	c := withFieldID(build(t, Message("m").Required("id", Int64()).Optional("name", String())), "name", 3)
+
+	_, err = Merge(a, c)
+	require.Error(t, err)
+}
+
+func TestMerge_Nested(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").List("items", Group().Required("sku", String())))
+	b := build(t, Message("m").List("items", Group().Required("sku", String()).Required("qty", Int32())))
+
+	merged, err := Merge(a, b)
+	require.NoError(t, err)
+
+	expected := build(t, Message("m").List("items", Group().Required("sku", String()).Optional("qty", Int32())))
+	assert.Empty(t, Compare(expected, merged))
+	assert.NoError(t, Validate(merged))
+}
+
+func TestMerge_Conflicts(t *testing.T) {
+	t.Parallel()
+
+	a := build(t, Message("m").Required("a", Int64()).Required("b", String()).Optional("c", Int64()).Required("d", Int64()))
+	b := build(t, Message("m").Required("a", String()).Required("b", Group().Required("x", Int32())).
+		Repeated("c", Int64()).Required("d", Int64()))
+
+	merged, err := Merge(a, b)
+	require.Error(t, err)
+	require.IsType(t, MergeConflicts{}, err)
+
+	conflicts := err.(MergeConflicts) //nolint:errorlint // checked above
+	require.Len(t, conflicts, 3)
+	assert.Equal(t, "a", conflicts[0].Path)
+	assert.Equal(t, "b", conflicts[1].Path)
+	assert.Equal(t, "c", conflicts[2].Path)
+	assert.Equal(t, "a: required INT64 can't be merged with required BYTE_ARRAY STRING", conflicts[0].Error())
+
+	// The conflicting columns keep their first definition.
+	require.NotNil(t, merged)
+	assert.Empty(t, Compare(a, merged))
+}
+
+func TestMerge_Empty(t *testing.T) {
+	t.Parallel()
+
+	_, err := Merge()
+	assert.Error(t, err)
+
+	a := build(t, Message("m").Required("id", Int64()))
+
+	merged, err := Merge(nil, a)
+	require.NoError(t, err)
+	assert.Empty(t, Compare(a, merged))
+}
+
+func childByName(children []*ColumnDefinition, name string) *ColumnDefinition {
+	for _, c := range children {
+		if c.SchemaElement.GetName() == name {
+			return c
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"github.com/hexbee-net/parquet/internal/columnpath"
+)
+
+const (
+	pathSeparator = '.'
+	pathEscape    = '\\'
+)
+
+// ParsePath splits a dotted column path into the names of the column and of its parents.
+// A backslash escapes the next character, so that "a\.b.c" is the column "c" of the
+// group "a.b". A trailing backslash is kept as is.
+func ParsePath(s string) []string {
+	return columnpath.Parse(s)
+}
+
+// FormatPath joins the names of a column path with dots, escaping the dots and the
+// backslashes of the names. It is the reverse of ParsePath.
+func FormatPath(path []string) string {
+	return columnpath.Format(path)
+}
+
+// PathEqual tells if a and b are the same column path.
+func PathEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pathHasPrefix tells if the column path starts with the names of prefix.
+func pathHasPrefix(path, prefix []string) bool {
+	return len(path) >= len(prefix) && PathEqual(path[:len(prefix)], prefix)
+}
+
+// childPath returns a new path with the name appended to the parent path.
+func childPath(parent []string, name string) []string {
+	return append(append(make([]string, 0, len(parent)+1), parent...), name)
+}
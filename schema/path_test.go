@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	t.Run("Parse", TestPath_Parse)
+	t.Run("Format", TestPath_Format)
+	t.Run("Lookup", TestPath_Lookup)
+	t.Run("Selection", TestPath_Selection)
+}
+
+func TestPath_Parse(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		path     string
+		expected []string
+	}{
+		{path: "", expected: nil},
+		{path: "a", expected: []string{"a"}},
+		{path: "a.b.c", expected: []string{"a", "b", "c"}},
+		{path: `a\.b.c`, expected: []string{"a.b", "c"}},
+		{path: `a\\.b`, expected: []string{`a\`, "b"}},
+		{path: `a\b`, expected: []string{"ab"}},
+		{path: `a.`, expected: []string{"a", ""}},
+		{path: `a\`, expected: []string{`a\`}},
+	} {
+		assert.Equal(t, tt.expected, ParsePath(tt.path), tt.path)
+	}
+}
+
+func TestPath_Format(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range [][]string{
+		{"a"},
+		{"a", "b"},
+		{"a.b", "c"},
+		{`a\`, "b.c", `\.`},
+	} {
+		assert.Equal(t, path, ParsePath(FormatPath(path)))
+	}
+
+	assert.Equal(t, `a\.b.c`, FormatPath([]string{"a.b", "c"}))
+}
+
+// dottedSchema has a column named "a.b" and a column "b" in the group "a".
+func dottedSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	s, err := LoadSchema([]*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 2),
+		leaf("a.b", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT64),
+		group("a", parquet.FieldRepetitionType_REQUIRED, 1),
+		leaf("b", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestPath_Lookup(t *testing.T) {
+	t.Parallel()
+
+	s := dottedSchema(t)
+
+	dotted := s.GetColumnByPath([]string{"a.b"})
+	require.NotNil(t, dotted)
+	assert.Equal(t, parquet.Type_INT64, dotted.Element().GetType())
+	assert.Equal(t, []string{"a.b"}, dotted.Path())
+	assert.Equal(t, `a\.b`, dotted.FlatName())
+	assert.Equal(t, dotted, s.GetColumnByName(`a\.b`))
+
+	nested := s.GetColumnByPath([]string{"a", "b"})
+	require.NotNil(t, nested)
+	assert.Equal(t, parquet.Type_INT32, nested.Element().GetType())
+	assert.Equal(t, "a.b", nested.FlatName())
+	assert.Equal(t, nested, s.GetColumnByName("a.b"))
+
+	assert.Nil(t, s.GetColumnByPath([]string{"a"}))
+}
+
+func TestPath_Selection(t *testing.T) {
+	t.Parallel()
+
+	s := dottedSchema(t)
+
+	require.NoError(t, s.SetSelectedColumns(`a\.b`))
+	assert.True(t, s.IsPathSelected([]string{"a.b"}))
+	assert.False(t, s.IsPathSelected([]string{"a", "b"}))
+
+	require.NoError(t, s.SetSelectedColumns("a"))
+	assert.False(t, s.IsPathSelected([]string{"a.b"}))
+	assert.True(t, s.IsPathSelected([]string{"a", "b"}))
+	assert.True(t, s.IsSelected("a.b"))
+
+	require.NoError(t, s.SetSelectedPaths([]string{"a", "b"}))
+	assert.True(t, s.IsPathSelected([]string{"a", "b"}))
+	assert.False(t, s.IsPathSelected([]string{"a.b"}))
+
+	require.NoError(t, s.SetSelectedPaths())
+	assert.True(t, s.IsPathSelected([]string{"a.b"}))
+}
@@ -1,18 +1,141 @@
 package schema
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexbee-net/parquet/parquet"
+)
+
 // SchemaDefinition represents a valid textual schema definition.
 type SchemaDefinition struct {
 	RootColumn *ColumnDefinition
 }
 
+// ParseSchemaDefinition parses a textual schema definition, in the format of the
+// schemas printed by the parquet tools:
+//
+//	message m {
+//	  required int64 id = 1;
+//	  optional binary name (STRING);
+//	  optional group tags (LIST) {
+//	    repeated group list {
+//	      required int32 element (INTEGER(16,true));
+//	    }
+//	  }
+//	}
+//
+// Each field has a repetition, a physical type or group, a name, and optionally a logical
+// or converted type in parentheses and a field ID. The physical types are the ones of the
+// parquet format in lower case, with binary for BYTE_ARRAY and the length of the
+// FIXED_LEN_BYTE_ARRAY fields in parentheses, like fixed_len_byte_array(16). The
+// converted type matching a logical type is set with it. A name is any run of characters
+// other than the whitespaces and ;{}()=, so names like user-id or a.b are accepted.
 func ParseSchemaDefinition(schemaText string) (*SchemaDefinition, error) {
-	panic("implement me")
+	return newSchemaParser(schemaText).message()
 }
 
 // String returns a textual representation of the schema definition. This textual representation
 // adheres to the format accepted by the ParseSchemaDefinition function. A textual schema definition
 // parsed by ParseSchemaDefinition and turned back into a string by this method repeatedly will
-// always remain the same, save for differences in the emitted whitespaces.
+// always remain the same, save for differences in the emitted whitespaces. The names holding
+// whitespaces or punctuation characters can't be parsed back.
 func (d *SchemaDefinition) String() string {
-	panic("implement me")
+	if d == nil || d.RootColumn == nil || d.RootColumn.SchemaElement == nil {
+		return ""
+	}
+
+	buf := &strings.Builder{}
+
+	fmt.Fprintf(buf, "message %s {\n", d.RootColumn.SchemaElement.GetName())
+
+	for _, c := range d.RootColumn.Children {
+		writeColumnDefinition(buf, c, 1)
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+func writeColumnDefinition(buf *strings.Builder, c *ColumnDefinition, depth int) {
+	elem := c.SchemaElement
+	indent := strings.Repeat("  ", depth)
+
+	fmt.Fprintf(buf, "%s%s %s %s%s", indent,
+		strings.ToLower(elem.GetRepetitionType().String()), textType(c), elem.GetName(), textAnnotation(elem))
+
+	if elem.IsSetFieldID() {
+		fmt.Fprintf(buf, " = %d", elem.GetFieldID())
+	}
+
+	if len(c.Children) == 0 {
+		buf.WriteString(";\n")
+		return
+	}
+
+	buf.WriteString(" {\n")
+
+	for _, child := range c.Children {
+		writeColumnDefinition(buf, child, depth+1)
+	}
+
+	fmt.Fprintf(buf, "%s}\n", indent)
+}
+
+func textType(c *ColumnDefinition) string {
+	switch t := c.SchemaElement.GetType(); {
+	case len(c.Children) > 0:
+		return "group"
+	case t == parquet.Type_BYTE_ARRAY:
+		return "binary"
+	case t == parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		return fmt.Sprintf("fixed_len_byte_array(%d)", c.SchemaElement.GetTypeLength())
+	default:
+		return strings.ToLower(t.String())
+	}
+}
+
+// textAnnotation returns the logical type of elem, or its converted type, in parentheses.
+func textAnnotation(elem *parquet.SchemaElement) string {
+	switch {
+	case elem.GetLogicalType() != nil && logicalTypeName(elem.GetLogicalType()) != "none":
+		return " (" + logicalTypeName(elem.GetLogicalType()) + ")"
+	case elem.IsSetConvertedType() && elem.GetConvertedType() == parquet.ConvertedType_DECIMAL:
+		return fmt.Sprintf(" (DECIMAL(%d,%d))", elem.GetPrecision(), elem.GetScale())
+	case elem.IsSetConvertedType():
+		return " (" + elem.GetConvertedType().String() + ")"
+	default:
+		return ""
+	}
+}
+
+// SchemaElements returns the flattened schema elements of the definition, in the
+// depth-first order of the file meta-data, with the number of children of the groups set.
+func (d *SchemaDefinition) SchemaElements() []*parquet.SchemaElement {
+	var (
+		res []*parquet.SchemaElement
+		fn  func(c *ColumnDefinition)
+	)
+
+	fn = func(c *ColumnDefinition) {
+		elem := *c.SchemaElement
+
+		if len(c.Children) > 0 {
+			n := int32(len(c.Children))
+			elem.NumChildren = &n
+		}
+
+		res = append(res, &elem)
+
+		for _, child := range c.Children {
+			fn(child)
+		}
+	}
+
+	if d != nil && d.RootColumn != nil {
+		fn(d.RootColumn)
+	}
+
+	return res
 }
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchemaText = `message m {
+  required int64 id = 1;
+  optional binary name (STRING);
+  optional group tags (LIST) {
+    repeated group list {
+      optional fixed_len_byte_array(16) element (UUID);
+    }
+  }
+  optional group attrs (MAP) {
+    repeated group key_value {
+      required binary key (STRING);
+      optional int32 value (INTEGER(16,false));
+    }
+  }
+  required int64 price (DECIMAL(18,2));
+  required int64 at (TIMESTAMP(MICROS,true));
+  required fixed_len_byte_array(12) duration (INTERVAL);
+  required double ratio;
+}
+`
+
+func TestSchemaDefinition(t *testing.T) {
+	t.Run("Parse", TestSchemaDefinition_Parse)
+	t.Run("String", TestSchemaDefinition_String)
+	t.Run("Errors", TestSchemaDefinition_Errors)
+}
+
+func TestSchemaDefinition_Parse(t *testing.T) {
+	t.Parallel()
+
+	def, err := ParseSchemaDefinition(testSchemaText)
+	require.NoError(t, err)
+	require.NoError(t, Validate(def))
+
+	root := def.RootColumn
+	assert.Equal(t, "m", root.SchemaElement.GetName())
+	require.Len(t, root.Children, 8)
+
+	id := root.Children[0].SchemaElement
+	assert.Equal(t, parquet.Type_INT64, id.GetType())
+	assert.Equal(t, parquet.FieldRepetitionType_REQUIRED, id.GetRepetitionType())
+	assert.Equal(t, int32(1), id.GetFieldID())
+
+	name := root.Children[1].SchemaElement
+	assert.Equal(t, parquet.Type_BYTE_ARRAY, name.GetType())
+	assert.True(t, name.GetLogicalType().IsSetSTRING())
+	assert.Equal(t, parquet.ConvertedType_UTF8, name.GetConvertedType())
+
+	element := root.Children[2].Children[0].Children[0].SchemaElement
+	assert.Equal(t, int32(16), element.GetTypeLength())
+	assert.True(t, element.GetLogicalType().IsSetUUID())
+
+	price := root.Children[4].SchemaElement
+	assert.Equal(t, int32(18), price.GetPrecision())
+	assert.Equal(t, int32(2), price.GetScale())
+	assert.Equal(t, parquet.ConvertedType_DECIMAL, price.GetConvertedType())
+
+	duration := root.Children[6].SchemaElement
+	assert.Nil(t, duration.GetLogicalType())
+	assert.Equal(t, parquet.ConvertedType_INTERVAL, duration.GetConvertedType())
+
+	// The parsed definition can be loaded.
+	s, err := LoadSchema(def.SchemaElements())
+	require.NoError(t, err)
+	assert.Empty(t, Compare(def, s.GetSchemaDefinition()))
+}
+
+func TestSchemaDefinition_String(t *testing.T) {
+	t.Parallel()
+
+	def, err := ParseSchemaDefinition(testSchemaText)
+	require.NoError(t, err)
+	assert.Equal(t, testSchemaText, def.String())
+
+	def, err = ParseSchemaDefinition("message m{required BINARY a(UTF8);}")
+	require.NoError(t, err)
+	assert.Equal(t, "message m {\n  required binary a (UTF8);\n}\n", def.String())
+
+	// The names aren't limited to identifiers.
+	text := "message m.v1 {\n  required int64 user-id;\n  optional group a.b {\n    optional binary $c (STRING);\n  }\n}\n"
+
+	def, err = ParseSchemaDefinition(text)
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", def.RootColumn.Children[0].SchemaElement.GetName())
+	assert.Equal(t, "a.b", def.RootColumn.Children[1].SchemaElement.GetName())
+	assert.Equal(t, text, def.String())
+
+	again, err := ParseSchemaDefinition(def.String())
+	require.NoError(t, err)
+	assert.Empty(t, Compare(def, again))
+
+	assert.Equal(t, "", (*SchemaDefinition)(nil).String())
+}
+
+func TestSchemaDefinition_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{
+		"",
+		"message {}",
+		"message m {}",
+		"message m { required int32 a; } trailing",
+		"message m { required int32 a }",
+		"message m { required int32 a; required int64 a; }",
+		"message m { mandatory int32 a; }",
+		"message m { required integer a; }",
+		"message m { required fixed_len_byte_array a; }",
+		"message m { required int32 a (FOO); }",
+		"message m { required int32 a (DECIMAL(9)); }",
+		"message m { required int64 a (TIMESTAMP(SECONDS,true)); }",
+		"message m { required int32 a (STRING(1)); }",
+		"message m { required int32 a = b; }",
+		"message m { optional group g { }",
+	} {
+		_, err := ParseSchemaDefinition(text)
+		assert.Error(t, err, text)
+	}
+}
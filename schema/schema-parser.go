@@ -0,0 +1,411 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+// schemaParser parses the textual schema definitions, in the format of the schemas
+// printed by the parquet tools:
+//
+//	message m {
+//	  required int64 id = 1;
+//	  optional binary name (STRING);
+//	  optional group tags (LIST) {
+//	    repeated group list {
+//	      optional fixed_len_byte_array(16) element (UUID);
+//	    }
+//	  }
+//	}
+type schemaParser struct {
+	text string
+	pos  int
+	line int
+	// tok is the current token, empty at the end of the text.
+	tok string
+}
+
+func newSchemaParser(text string) *schemaParser {
+	p := &schemaParser{text: text, line: 1}
+	p.next()
+
+	return p
+}
+
+const (
+	schemaSpaces      = " \t\r\n"
+	schemaPunctuation = ";{}()=,"
+)
+
+// isNameChar tells if c can be part of a name or a number: like in parquet-mr, the names
+// are the runs of characters other than the whitespaces and the punctuation.
+func isNameChar(c byte) bool {
+	return strings.IndexByte(schemaSpaces, c) < 0 && strings.IndexByte(schemaPunctuation, c) < 0
+}
+
+// next reads the next token: a name, a number or a punctuation character.
+func (p *schemaParser) next() {
+	for p.pos < len(p.text) && strings.IndexByte(schemaSpaces, p.text[p.pos]) >= 0 {
+		if p.text[p.pos] == '\n' {
+			p.line++
+		}
+
+		p.pos++
+	}
+
+	start := p.pos
+
+	switch {
+	case p.pos == len(p.text):
+	case isNameChar(p.text[p.pos]):
+		for p.pos < len(p.text) && isNameChar(p.text[p.pos]) {
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+
+	p.tok = p.text[start:p.pos]
+}
+
+func (p *schemaParser) errorf(format string, args ...interface{}) error {
+	return errors.WithFields(
+		errors.Errorf(format, args...),
+		errors.Fields{
+			"line": p.line,
+		})
+}
+
+func (p *schemaParser) unexpected(expected string) error {
+	if p.tok == "" {
+		return p.errorf("unexpected end of schema, expected %s", expected)
+	}
+
+	return p.errorf("unexpected %q, expected %s", p.tok, expected)
+}
+
+func (p *schemaParser) expect(tok string) error {
+	if p.tok != tok {
+		return p.unexpected(strconv.Quote(tok))
+	}
+
+	p.next()
+
+	return nil
+}
+
+func (p *schemaParser) name() (string, error) {
+	if p.tok == "" || !isNameChar(p.tok[0]) {
+		return "", p.unexpected("a name")
+	}
+
+	name := p.tok
+	p.next()
+
+	return name, nil
+}
+
+func (p *schemaParser) integer() (int32, error) {
+	n, err := strconv.ParseInt(p.tok, 10, 32)
+	if err != nil {
+		return 0, p.unexpected("an integer")
+	}
+
+	p.next()
+
+	return int32(n), nil
+}
+
+func (p *schemaParser) message() (*SchemaDefinition, error) {
+	if err := p.expect("message"); err != nil {
+		return nil, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := p.fields()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok != "" {
+		return nil, p.unexpected("the end of the schema")
+	}
+
+	return &SchemaDefinition{
+		RootColumn: &ColumnDefinition{
+			SchemaElement: &parquet.SchemaElement{
+				Name:           name,
+				RepetitionType: parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED),
+			},
+			Children: children,
+		},
+	}, nil
+}
+
+// fields parses the fields of a group, between braces.
+func (p *schemaParser) fields() ([]*ColumnDefinition, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var (
+		children []*ColumnDefinition
+		names    = make(map[string]bool)
+	)
+
+	for p.tok != "}" {
+		c, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+
+		name := c.SchemaElement.GetName()
+		if names[name] {
+			return nil, p.errorf("duplicate field name %q", name)
+		}
+
+		names[name] = true
+		children = append(children, c)
+	}
+
+	if len(children) == 0 {
+		return nil, p.errorf("group without field")
+	}
+
+	p.next()
+
+	return children, nil
+}
+
+func (p *schemaParser) field() (*ColumnDefinition, error) {
+	rep, err := parquet.FieldRepetitionTypeFromString(strings.ToUpper(p.tok))
+	if err != nil {
+		return nil, p.unexpected("required, optional or repeated")
+	}
+
+	p.next()
+
+	elem := &parquet.SchemaElement{RepetitionType: parquet.FieldRepetitionTypePtr(rep)}
+	group := p.tok == "group"
+
+	if group {
+		p.next()
+	} else if err := p.physicalType(elem); err != nil {
+		return nil, err
+	}
+
+	if elem.Name, err = p.name(); err != nil {
+		return nil, err
+	}
+
+	if err := p.annotation(elem); err != nil {
+		return nil, err
+	}
+
+	if p.tok == "=" {
+		p.next()
+
+		id, err := p.integer()
+		if err != nil {
+			return nil, err
+		}
+
+		elem.FieldID = &id
+	}
+
+	c := &ColumnDefinition{SchemaElement: elem}
+
+	if group {
+		c.Children, err = p.fields()
+	} else {
+		err = p.expect(";")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (p *schemaParser) physicalType(elem *parquet.SchemaElement) error {
+	name := strings.ToUpper(p.tok)
+	if name == "BINARY" {
+		name = parquet.Type_BYTE_ARRAY.String()
+	}
+
+	typ, err := parquet.TypeFromString(name)
+	if err != nil {
+		return p.unexpected("group or a physical type")
+	}
+
+	elem.Type = parquet.TypePtr(typ)
+	p.next()
+
+	if typ != parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		return nil
+	}
+
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	length, err := p.integer()
+	if err != nil {
+		return err
+	}
+
+	elem.TypeLength = &length
+
+	return p.expect(")")
+}
+
+// annotation parses the optional logical or converted type of a field, like (STRING) or (DECIMAL(9,2)).
+func (p *schemaParser) annotation(elem *parquet.SchemaElement) error {
+	if p.tok != "(" {
+		return nil
+	}
+
+	p.next()
+
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	var args []string
+
+	if p.tok == "(" {
+		for p.next(); ; p.next() {
+			args = append(args, p.tok)
+
+			if p.next(); p.tok != "," {
+				break
+			}
+		}
+
+		if err := p.expect(")"); err != nil {
+			return err
+		}
+	}
+
+	if err := p.setAnnotation(elem, strings.ToUpper(name), args); err != nil {
+		return err
+	}
+
+	return p.expect(")")
+}
+
+//nolint:funlen,gocyclo // one case per annotation
+func (p *schemaParser) setAnnotation(elem *parquet.SchemaElement, name string, args []string) error {
+	lt := &parquet.LogicalType{}
+	nargs := 0
+
+	switch name {
+	case "STRING":
+		lt.STRING = &parquet.StringType{}
+	case "ENUM":
+		lt.ENUM = &parquet.EnumType{}
+	case "UUID":
+		lt.UUID = &parquet.UUIDType{}
+	case "JSON":
+		lt.JSON = &parquet.JsonType{}
+	case "BSON":
+		lt.BSON = &parquet.BsonType{}
+	case "DATE":
+		lt.DATE = &parquet.DateType{}
+	case "UNKNOWN":
+		lt.UNKNOWN = &parquet.NullType{}
+	case "LIST":
+		lt.LIST = &parquet.ListType{}
+	case "MAP":
+		lt.MAP = &parquet.MapType{}
+		elem.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_MAP)
+	case "DECIMAL":
+		nargs = 2
+		precision, errP := strconv.ParseInt(argAt(args, 0), 10, 32)
+		scale, errS := strconv.ParseInt(argAt(args, 1), 10, 32)
+
+		if errP != nil || errS != nil {
+			return p.errorf("invalid DECIMAL parameters %q", args)
+		}
+
+		p32, s32 := int32(precision), int32(scale)
+		lt.DECIMAL = &parquet.DecimalType{Precision: p32, Scale: s32}
+		elem.Precision, elem.Scale = &p32, &s32
+	case "TIME", "TIMESTAMP":
+		nargs = 2
+		unit := parseTimeUnit(argAt(args, 0))
+		utc, err := strconv.ParseBool(argAt(args, 1))
+
+		if unit == nil || err != nil {
+			return p.errorf("invalid %s parameters %q", name, args)
+		}
+
+		if name == "TIME" {
+			lt.TIME = &parquet.TimeType{IsAdjustedToUTC: utc, Unit: unit}
+		} else {
+			lt.TIMESTAMP = &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: unit}
+		}
+	case "INTEGER":
+		nargs = 2
+		bitWidth, errW := strconv.ParseInt(argAt(args, 0), 10, 8)
+		signed, errS := strconv.ParseBool(argAt(args, 1))
+
+		if errW != nil || errS != nil {
+			return p.errorf("invalid INTEGER parameters %q", args)
+		}
+
+		lt.INTEGER = &parquet.IntType{BitWidth: int8(bitWidth), IsSigned: signed}
+	default:
+		ct, err := parquet.ConvertedTypeFromString(name)
+		if err != nil {
+			return p.errorf("unknown annotation %s", name)
+		}
+
+		lt = nil
+		elem.ConvertedType = parquet.ConvertedTypePtr(ct)
+	}
+
+	if len(args) != nargs {
+		return p.errorf("annotation %s takes %d parameters, not %d", name, nargs, len(args))
+	}
+
+	if lt != nil {
+		elem.LogicalType = lt
+
+		if ct, ok := convertedTypeOf(lt); ok {
+			elem.ConvertedType = parquet.ConvertedTypePtr(ct)
+		}
+	}
+
+	return nil
+}
+
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+
+	return ""
+}
+
+func parseTimeUnit(s string) *parquet.TimeUnit {
+	switch strings.ToUpper(s) {
+	case "MILLIS":
+		return &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}
+	case "MICROS":
+		return &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}
+	case "NANOS":
+		return &parquet.TimeUnit{NANOS: &parquet.NanoSeconds{}}
+	default:
+		return nil
+	}
+}
@@ -1,10 +1,9 @@
 package schema
 
 import (
-	"strings"
-
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/datastore"
+	"github.com/hexbee-net/parquet/logical"
 	"github.com/hexbee-net/parquet/parquet"
 )
 
@@ -17,6 +16,8 @@ type schemaCommon interface {
 
 	// Return a column by its name
 	GetColumnByName(path string) *Column
+	// GetColumnByPath returns a column by the names of its parents and its name.
+	GetColumnByPath(path []string) *Column
 
 	// GetSchemaDefinition returns the schema definition.
 	GetSchemaDefinition() *SchemaDefinition
@@ -34,8 +35,12 @@ type Reader interface {
 
 	SetNumRecords(int64)
 	GetData() (map[string]interface{}, error)
-	SetSelectedColumns(selected ...string)
+	SetSelectedColumns(selected ...string) error
+	SetSelectedPaths(selected ...[]string) error
 	IsSelected(string) bool
+	IsPathSelected(path []string) bool
+	SetLogicalAssembly(enabled bool)
+	SetLogicalConversion(opts *logical.Options)
 }
 
 // Writer is an interface with methods necessary in the FileWriter
@@ -50,11 +55,12 @@ type Writer interface {
 }
 
 type Schema struct {
-	schemaDef      *SchemaDefinition
-	Root           *Column
-	numRecords     int64
-	readOnly       bool
-	selectedColumn []string // selected columns in reading. Empty means all the columns.
+	schemaDef  *SchemaDefinition
+	Root       *Column
+	numRecords int64
+	readOnly   bool
+	selection  *selection // selected columns in reading. Nil means all the columns.
+	logical    bool       // logical assembly of the LIST and MAP groups in reading.
 }
 
 func LoadSchema(schema []*parquet.SchemaElement) (s *Schema, err error) {
@@ -88,9 +94,9 @@ func LoadSchema(schema []*parquet.SchemaElement) (s *Schema, err error) {
 		c := &Column{}
 
 		if schema[idx].Type == nil {
-			idx, err = c.readGroupSchema(schema, "", idx, 0, 0)
+			idx, err = c.readGroupSchema(schema, nil, idx, 0, 0)
 		} else {
-			idx, err = c.readColumnSchema(schema, "", idx, 0, 0)
+			idx, err = c.readColumnSchema(schema, nil, idx, 0, 0)
 		}
 
 		if err != nil {
@@ -107,6 +113,20 @@ func LoadSchema(schema []*parquet.SchemaElement) (s *Schema, err error) {
 	return s, nil
 }
 
+// LoadSchemaStrict is LoadSchema in strict mode: the schema must also pass Validate.
+func LoadSchemaStrict(schema []*parquet.SchemaElement) (*Schema, error) {
+	s, err := LoadSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Validate(s.schemaDef); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
 func (s *Schema) Columns() []*Column {
 	var (
 		ret []*Column
@@ -129,10 +149,17 @@ func (s *Schema) Columns() []*Column {
 	return ret
 }
 
+// GetColumnByName returns the data column with the given path in dotted notation,
+// where the dots of the names are escaped with a backslash (see ParsePath).
 func (s *Schema) GetColumnByName(path string) *Column {
+	return s.GetColumnByPath(ParsePath(path))
+}
+
+// GetColumnByPath returns the data column with the given path.
+func (s *Schema) GetColumnByPath(path []string) *Column {
 	data := s.Columns()
 	for i := range data {
-		if data[i].flatName == path {
+		if PathEqual(data[i].nameArray, path) {
 			return data[i]
 		}
 	}
@@ -140,14 +167,13 @@ func (s *Schema) GetColumnByName(path string) *Column {
 	return nil
 }
 
+// GetSchemaDefinition returns a new schema definition built from the columns of the schema.
 func (s *Schema) GetSchemaDefinition() *SchemaDefinition {
-	//TODO: Check what's going on here
-	def, err := ParseSchemaDefinition(s.schemaDef.String())
-	if err != nil {
-		panic(err)
+	if s.Root == nil {
+		return s.schemaDef
 	}
 
-	return def
+	return s.Root.AsColumnDefinition().AsSchemaDefinition()
 }
 
 func (s *Schema) SetSchemaDefinition(schemaDefinition *SchemaDefinition) error {
@@ -161,13 +187,23 @@ func (s *Schema) SetSchemaDefinition(schemaDefinition *SchemaDefinition) error {
 	s.Root = root
 
 	for _, c := range s.Root.children {
-		recursiveFix(c, "", 0, 0)
+		recursiveFix(c, nil, 0, 0)
 	}
 
 	return nil
 }
 
-//TODO: rename to GetNumRecords.
+// SetSchemaDefinitionStrict is SetSchemaDefinition in strict mode: the schema definition
+// must also pass Validate. The schema is left unchanged when it doesn't.
+func (s *Schema) SetSchemaDefinitionStrict(schemaDefinition *SchemaDefinition) error {
+	if err := Validate(schemaDefinition); err != nil {
+		return err
+	}
+
+	return s.SetSchemaDefinition(schemaDefinition)
+}
+
+// TODO: rename to GetNumRecords.
 func (s *Schema) RowGroupNumRecords() int64 {
 	return s.numRecords
 }
@@ -206,29 +242,13 @@ func (s *Schema) GetData() (map[string]interface{}, error) {
 		d = make(map[string]interface{}) // just non nil root doc
 	}
 
-	return d.(map[string]interface{}), nil
-}
-
-func (s *Schema) SetSelectedColumns(selected ...string) {
-	s.selectedColumn = selected
-}
-
-func (s *Schema) IsSelected(colPath string) bool {
-	if len(s.selectedColumn) == 0 {
-		return true
-	}
-
-	for _, pattern := range s.selectedColumn {
-		if pattern == colPath {
-			return true
-		}
-
-		if strings.HasPrefix(colPath, pattern+".") {
-			return true
+	if s.logical {
+		if d, err = s.Root.assembleGroup(d.(map[string]interface{})); err != nil {
+			return nil, err
 		}
 	}
 
-	return false
+	return d.(map[string]interface{}), nil
 }
 
 func (s *Schema) sortIndex() {
@@ -271,7 +291,7 @@ func (s *Schema) ensureRoot() {
 	}
 }
 
-func recursiveFix(col *Column, path string, maxR, maxD uint16) {
+func recursiveFix(col *Column, parent []string, maxR, maxD uint16) {
 	if col.rep != parquet.FieldRepetitionType_REQUIRED {
 		maxD++
 	}
@@ -282,11 +302,7 @@ func recursiveFix(col *Column, path string, maxR, maxD uint16) {
 
 	col.maxR = maxR
 	col.maxD = maxD
-	col.flatName = path + "." + col.name
-
-	if path == "" {
-		col.flatName = col.name
-	}
+	col.setPath(childPath(parent, col.name))
 
 	if col.data != nil {
 		col.data.Reset(col.rep, col.maxR, col.maxD)
@@ -294,6 +310,6 @@ func recursiveFix(col *Column, path string, maxR, maxD uint16) {
 	}
 
 	for i := range col.children {
-		recursiveFix(col.children[i], col.flatName, maxR, maxD)
+		recursiveFix(col.children[i], col.nameArray, maxR, maxD)
 	}
 }
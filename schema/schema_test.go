@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSchema(t *testing.T) {
+	t.Run("Decimal", TestLoadSchema_Decimal)
+	t.Run("InvalidDecimal", TestLoadSchema_InvalidDecimal)
+}
+
+func decimalSchema(typ parquet.Type, precision, scale int32) []*parquet.SchemaElement {
+	return []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 1),
+		annotated(leaf("amount", parquet.FieldRepetitionType_REQUIRED, typ),
+			&parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}, nil),
+	}
+}
+
+func TestLoadSchema_Decimal(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadSchema(decimalSchema(parquet.Type_INT64, 18, 2))
+	assert.NoError(t, err)
+}
+
+func TestLoadSchema_InvalidDecimal(t *testing.T) {
+	t.Parallel()
+
+	for _, elems := range [][]*parquet.SchemaElement{
+		decimalSchema(parquet.Type_INT32, 12, 2),
+		decimalSchema(parquet.Type_INT64, 10, 12),
+	} {
+		// The default loader is lenient, the values are read as they are stored.
+		_, err := LoadSchema(elems)
+		assert.NoError(t, err)
+
+		_, err = LoadSchemaStrict(elems)
+		assert.Error(t, err)
+	}
+}
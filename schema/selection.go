@@ -0,0 +1,204 @@
+package schema
+
+import (
+	"path"
+	"strings"
+
+	"github.com/hexbee-net/errors"
+)
+
+const negation = '!'
+
+// selector is a pattern of a column selection: the glob patterns of the names of a
+// column path, matching the columns of the path and of its children.
+type selector struct {
+	pattern string
+	names   []string
+	negated bool
+}
+
+// selection is a set of column selectors. A column is selected when it matches one of
+// the positive selectors, or when there is none, and none of the negated selectors.
+type selection struct {
+	selectors []*selector
+}
+
+func (sel *selector) match(p []string) bool {
+	if len(p) < len(sel.names) {
+		return false
+	}
+
+	for i, name := range sel.names {
+		if ok, _ := path.Match(name, p[i]); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (sel *selection) isSelected(p []string) bool {
+	positive, selected := false, false
+
+	for _, s := range sel.selectors {
+		switch {
+		case s.negated && s.match(p):
+			return false
+		case !s.negated:
+			positive = true
+			selected = selected || s.match(p)
+		}
+	}
+
+	return selected || !positive
+}
+
+// parseSelector parses a selection pattern in dotted notation. The names may hold the
+// glob patterns of path.Match, and a leading "!" negates the pattern. A backslash
+// escapes a dot, a glob special character or a leading "!".
+func parseSelector(pattern string) (*selector, error) {
+	sel := &selector{pattern: pattern}
+
+	s := pattern
+	if len(s) > 0 && s[0] == negation {
+		sel.negated = true
+		s = s[1:]
+	}
+
+	var name strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == pathEscape && i+1 < len(s) && s[i+1] == pathSeparator:
+			i++
+			name.WriteByte(pathSeparator)
+		case ch == pathEscape && i+1 < len(s) && s[i+1] == negation:
+			i++
+			name.WriteByte(negation)
+		case ch == pathEscape && i+1 < len(s):
+			// kept for path.Match
+			name.WriteByte(ch)
+			i++
+			name.WriteByte(s[i])
+		case ch == pathSeparator:
+			sel.names = append(sel.names, name.String())
+			name.Reset()
+		default:
+			name.WriteByte(ch)
+		}
+	}
+
+	sel.names = append(sel.names, name.String())
+
+	for _, n := range sel.names {
+		if _, err := path.Match(n, ""); err != nil {
+			return nil, errors.WithFields(
+				errors.Wrap(err, "invalid column selection"),
+				errors.Fields{
+					"pattern": pattern,
+				})
+		}
+	}
+
+	return sel, nil
+}
+
+// escapeGlob escapes the glob special characters of a name.
+func escapeGlob(name string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(name); i++ {
+		if strings.IndexByte(`*?[]\`, name[i]) >= 0 {
+			b.WriteByte(pathEscape)
+		}
+
+		b.WriteByte(name[i])
+	}
+
+	return b.String()
+}
+
+// SetSelectedColumns selects the columns to read by patterns in dotted notation (see
+// ParsePath). A pattern selects the columns of a path and all their children. The names
+// of a pattern may hold the glob patterns of path.Match, like "payload.*.id", and a
+// pattern starting with "!" excludes the columns it matches, like "!debug.*".
+// It fails if a pattern is invalid or matches no column.
+func (s *Schema) SetSelectedColumns(selected ...string) error {
+	sel := &selection{}
+
+	for _, pattern := range selected {
+		selector, err := parseSelector(pattern)
+		if err != nil {
+			return err
+		}
+
+		sel.selectors = append(sel.selectors, selector)
+	}
+
+	return s.setSelection(sel)
+}
+
+// SetSelectedPaths selects the columns to read by their paths. Selecting a group
+// selects all its columns. It fails if a path matches no column.
+func (s *Schema) SetSelectedPaths(selected ...[]string) error {
+	sel := &selection{}
+
+	for _, p := range selected {
+		names := make([]string, len(p))
+		for i := range p {
+			names[i] = escapeGlob(p[i])
+		}
+
+		sel.selectors = append(sel.selectors, &selector{pattern: FormatPath(p), names: names})
+	}
+
+	return s.setSelection(sel)
+}
+
+func (s *Schema) setSelection(sel *selection) error {
+	if len(sel.selectors) == 0 {
+		s.selection = nil
+		return nil
+	}
+
+	columns := s.Columns()
+
+	var unmatched []string
+
+	for _, selector := range sel.selectors {
+		matched := false
+
+		for _, c := range columns {
+			if selector.match(c.nameArray) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			unmatched = append(unmatched, selector.pattern)
+		}
+	}
+
+	if len(unmatched) > 0 {
+		return errors.WithFields(
+			errors.New("column selection matches no column"),
+			errors.Fields{
+				"patterns": strings.Join(unmatched, ", "),
+			})
+	}
+
+	s.selection = sel
+
+	return nil
+}
+
+// IsSelected tells if the column with the given path in dotted notation is selected.
+func (s *Schema) IsSelected(colPath string) bool {
+	return s.IsPathSelected(ParsePath(colPath))
+}
+
+// IsPathSelected tells if the column with the given path is selected.
+func (s *Schema) IsPathSelected(p []string) bool {
+	return s.selection == nil || s.selection.isSelected(p)
+}
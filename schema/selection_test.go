@@ -0,0 +1,162 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Selection(t *testing.T) {
+	t.Run("Glob", TestSchema_Selection_Glob)
+	t.Run("Negated", TestSchema_Selection_Negated)
+	t.Run("Escaped", TestSchema_Selection_Escaped)
+	t.Run("Unmatched", TestSchema_Selection_Unmatched)
+	t.Run("Invalid", TestSchema_Selection_Invalid)
+	t.Run("NestedList", TestSchema_Selection_NestedList)
+}
+
+func selectionSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	def, err := Message("m").
+		Required("id", Int64()).
+		Group("payload", Group().
+			Group("user", Group().Required("id", Int64()).Optional("name", String())).
+			Group("order", Group().Required("id", Int64()).Optional("total", Double()))).
+		Group("metrics", Group().Required("cpu", Double()).Required("mem", Double())).
+		Group("debug", Group().Optional("trace", String()).Optional("span", String())).
+		Optional("a*b", Int32()).
+		Build()
+	require.NoError(t, err)
+
+	s, err := LoadSchema(def.SchemaElements())
+	require.NoError(t, err)
+
+	return s
+}
+
+// selected returns the paths of the selected columns.
+func selected(s *Schema) []string {
+	var res []string
+
+	for _, c := range s.Columns() {
+		if s.IsPathSelected(c.Path()) {
+			res = append(res, c.FlatName())
+		}
+	}
+
+	return res
+}
+
+func TestSchema_Selection_Glob(t *testing.T) {
+	t.Parallel()
+
+	s := selectionSchema(t)
+
+	require.NoError(t, s.SetSelectedColumns("payload.*.id"))
+	assert.Equal(t, []string{"payload.user.id", "payload.order.id"}, selected(s))
+
+	require.NoError(t, s.SetSelectedColumns("metrics.*", "id"))
+	assert.Equal(t, []string{"id", "metrics.cpu", "metrics.mem"}, selected(s))
+
+	require.NoError(t, s.SetSelectedColumns("*.*.?d"))
+	assert.Equal(t, []string{"payload.user.id", "payload.order.id"}, selected(s))
+
+	require.NoError(t, s.SetSelectedColumns("m[ae]*"))
+	assert.Equal(t, []string{"metrics.cpu", "metrics.mem"}, selected(s))
+}
+
+func TestSchema_Selection_Negated(t *testing.T) {
+	t.Parallel()
+
+	s := selectionSchema(t)
+
+	require.NoError(t, s.SetSelectedColumns("!debug.*", "!payload.order", "!a*"))
+	assert.Equal(t, []string{"id", "payload.user.id", "payload.user.name", "metrics.cpu", "metrics.mem"}, selected(s))
+
+	require.NoError(t, s.SetSelectedColumns("payload", "!payload.*.id"))
+	assert.Equal(t, []string{"payload.user.name", "payload.order.total"}, selected(s))
+}
+
+func TestSchema_Selection_Escaped(t *testing.T) {
+	t.Parallel()
+
+	s := selectionSchema(t)
+
+	require.NoError(t, s.SetSelectedColumns(`a\*b`))
+	assert.Equal(t, []string{"a*b"}, selected(s))
+
+	require.NoError(t, s.SetSelectedPaths([]string{"a*b"}))
+	assert.Equal(t, []string{"a*b"}, selected(s))
+}
+
+func TestSchema_Selection_Unmatched(t *testing.T) {
+	t.Parallel()
+
+	s := selectionSchema(t)
+
+	require.NoError(t, s.SetSelectedColumns("id"))
+
+	assert.Error(t, s.SetSelectedColumns("id", "payload.*.email"))
+	assert.Error(t, s.SetSelectedColumns("!nothing"))
+	assert.Error(t, s.SetSelectedPaths([]string{"payload", "*"}))
+
+	// The previous selection is kept.
+	assert.Equal(t, []string{"id"}, selected(s))
+}
+
+func TestSchema_Selection_Invalid(t *testing.T) {
+	t.Parallel()
+
+	s := selectionSchema(t)
+
+	assert.Error(t, s.SetSelectedColumns("metrics.[cpu"))
+}
+
+func TestSchema_Selection_NestedList(t *testing.T) {
+	t.Parallel()
+
+	s := loadTestSchema(t, []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 1),
+		annotated(group("items", parquet.FieldRepetitionType_OPTIONAL, 1), &parquet.LogicalType{LIST: parquet.NewListType()}, nil),
+		group("list", parquet.FieldRepetitionType_REPEATED, 1),
+		group("element", parquet.FieldRepetitionType_OPTIONAL, 2),
+		leaf("qty", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+		leaf("sku", parquet.FieldRepetitionType_REQUIRED, parquet.Type_BYTE_ARRAY),
+	}, map[string][]level{
+		"items.list.element.qty": nil,
+		"items.list.element.sku": {
+			{r: 0, d: 3, value: []byte("a")},
+			{r: 1, d: 3, value: []byte("b")},
+			{r: 0, d: 1},
+			{r: 0, d: 0},
+		},
+	})
+	s.SetLogicalAssembly(true)
+
+	require.NoError(t, s.SetSelectedColumns("items.list.element.sku"))
+
+	// As done by the file reader for the columns that are not selected.
+	for _, c := range s.Columns() {
+		if !s.IsPathSelected(c.Path()) {
+			c.SetSkipped(true)
+		}
+	}
+
+	expected := []map[string]interface{}{
+		{"items": []interface{}{
+			map[string]interface{}{"sku": []byte("a")},
+			map[string]interface{}{"sku": []byte("b")},
+		}},
+		{"items": []interface{}{}},
+		{},
+	}
+
+	for _, e := range expected {
+		d, err := s.GetData()
+		require.NoError(t, err)
+		assert.Equal(t, e, d)
+	}
+}
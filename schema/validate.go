@@ -0,0 +1,432 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexbee-net/parquet/logical"
+	"github.com/hexbee-net/parquet/parquet"
+)
+
+const uuidSize = 16
+
+// ValidationError is a violation of the rules of the parquet format by a column of a schema.
+type ValidationError struct {
+	// Path is the dotted path of the column, formatted by FormatPath, empty for the root.
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return "schema: " + e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors are the violations found by Validate, in the depth-first order of the columns.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msg := make([]string, len(e))
+	for i := range e {
+		msg[i] = e[i].Error()
+	}
+
+	return strings.Join(msg, "; ")
+}
+
+// Validate checks that the schema definition follows the rules of the parquet format
+// for the physical types, the repetitions and the logical and converted types of its
+// columns, as described in LogicalTypes.md. It returns nil, or ValidationErrors
+// listing all the violations found.
+func Validate(def *SchemaDefinition) error {
+	v := &validator{}
+
+	if def == nil || def.RootColumn == nil || def.RootColumn.SchemaElement == nil {
+		v.add(nil, "no root column")
+		return v.errs
+	}
+
+	root := def.RootColumn
+	if root.SchemaElement.Type != nil {
+		v.add(nil, "the root must be a group")
+	}
+
+	if len(root.Children) == 0 {
+		v.add(nil, "the root has no column")
+	}
+
+	for _, c := range root.Children {
+		v.column(c, nil)
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+// ValidateColumn is Validate for a single column definition and its children,
+// the paths of the errors start at the column.
+func ValidateColumn(c *ColumnDefinition) error {
+	v := &validator{}
+	v.column(c, nil)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(path []string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:    FormatPath(path),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) column(c *ColumnDefinition, parent []string) {
+	if c == nil || c.SchemaElement == nil {
+		v.add(parent, "column without schema element")
+		return
+	}
+
+	elem := c.SchemaElement
+	path := append(append([]string(nil), parent...), elem.GetName())
+
+	if elem.GetName() == "" {
+		v.add(path, "empty column name")
+	}
+
+	if !elem.IsSetRepetitionType() {
+		v.add(path, "no repetition type")
+	}
+
+	if len(c.Children) > 0 {
+		v.group(c, path)
+
+		for _, child := range c.Children {
+			v.column(child, path)
+		}
+
+		return
+	}
+
+	v.leaf(elem, path)
+}
+
+func (v *validator) group(c *ColumnDefinition, path []string) {
+	elem := c.SchemaElement
+	lt := elem.GetLogicalType()
+
+	if elem.Type != nil {
+		v.add(path, "group with physical type %s", elem.GetType())
+	}
+
+	switch {
+	case lt != nil && lt.IsSetLIST() || elem.IsSetConvertedType() && elem.GetConvertedType() == parquet.ConvertedType_LIST:
+		v.list(c, path)
+	case lt != nil && lt.IsSetMAP() || elem.IsSetConvertedType() && (elem.GetConvertedType() == parquet.ConvertedType_MAP ||
+		elem.GetConvertedType() == parquet.ConvertedType_MAP_KEY_VALUE):
+		v.mapGroup(c, path)
+	case lt != nil:
+		v.add(path, "logical type %s not allowed on a group", logicalTypeName(lt))
+	case elem.IsSetConvertedType():
+		v.add(path, "converted type %s not allowed on a group", elem.GetConvertedType())
+	}
+
+	if lt != nil && elem.IsSetConvertedType() {
+		v.matchConvertedType(elem, path)
+	}
+}
+
+func (v *validator) list(c *ColumnDefinition, path []string) {
+	if c.SchemaElement.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		v.add(path, "LIST group must be required or optional")
+	}
+
+	if len(c.Children) != 1 || c.Children[0].SchemaElement.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED {
+		v.add(path, "LIST group must have a single repeated field")
+	}
+}
+
+func (v *validator) mapGroup(c *ColumnDefinition, path []string) {
+	if c.SchemaElement.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		v.add(path, "MAP group must be required or optional")
+	}
+
+	if len(c.Children) != 1 {
+		v.add(path, "MAP group must have a single repeated key_value group")
+		return
+	}
+
+	kv := c.Children[0]
+	if kv.SchemaElement.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED || len(kv.Children) == 0 {
+		v.add(path, "MAP group must have a single repeated key_value group")
+		return
+	}
+
+	if len(kv.Children) > 2 {
+		v.add(path, "MAP key_value group must have a key field and an optional value field")
+	}
+
+	if key := kv.Children[0].SchemaElement; key.GetRepetitionType() != parquet.FieldRepetitionType_REQUIRED {
+		v.add(append(path, kv.SchemaElement.GetName(), key.GetName()), "MAP key must be required")
+	}
+}
+
+func (v *validator) leaf(elem *parquet.SchemaElement, path []string) {
+	if elem.Type == nil {
+		v.add(path, "leaf column without physical type")
+		return
+	}
+
+	if elem.GetType() == parquet.Type_FIXED_LEN_BYTE_ARRAY && elem.GetTypeLength() <= 0 {
+		v.add(path, "FIXED_LEN_BYTE_ARRAY without positive length")
+	}
+
+	if err := logical.ValidateDecimal(elem); err != nil {
+		v.add(path, "%s", err)
+	}
+
+	if lt := elem.GetLogicalType(); lt != nil {
+		if msg := checkLogicalType(lt, elem); msg != "" {
+			v.add(path, "%s", msg)
+		}
+	}
+
+	if elem.IsSetConvertedType() {
+		if msg := checkConvertedType(elem); msg != "" {
+			v.add(path, "%s", msg)
+		}
+
+		v.matchConvertedType(elem, path)
+	}
+}
+
+// matchConvertedType checks that the converted type of elem is the one of its logical type.
+func (v *validator) matchConvertedType(elem *parquet.SchemaElement, path []string) {
+	lt := elem.GetLogicalType()
+	if lt == nil {
+		return
+	}
+
+	expected, ok := convertedTypeOf(lt)
+	if ok && expected != elem.GetConvertedType() {
+		v.add(path, "converted type %s doesn't match logical type %s", elem.GetConvertedType(), logicalTypeName(lt))
+	}
+}
+
+// logicalTypeName returns the short name of a logical type, with its parameters, like DECIMAL(9,2).
+func logicalTypeName(lt *parquet.LogicalType) string {
+	switch {
+	case lt == nil:
+		return "none"
+	case lt.IsSetSTRING():
+		return "STRING"
+	case lt.IsSetMAP():
+		return "MAP"
+	case lt.IsSetLIST():
+		return "LIST"
+	case lt.IsSetENUM():
+		return "ENUM"
+	case lt.IsSetDECIMAL():
+		return fmt.Sprintf("DECIMAL(%d,%d)", lt.DECIMAL.Precision, lt.DECIMAL.Scale)
+	case lt.IsSetDATE():
+		return "DATE"
+	case lt.IsSetTIME():
+		return fmt.Sprintf("TIME(%s,%t)", unitName(lt.TIME.GetUnit()), lt.TIME.IsAdjustedToUTC)
+	case lt.IsSetTIMESTAMP():
+		return fmt.Sprintf("TIMESTAMP(%s,%t)", unitName(lt.TIMESTAMP.GetUnit()), lt.TIMESTAMP.IsAdjustedToUTC)
+	case lt.IsSetINTEGER():
+		return fmt.Sprintf("INTEGER(%d,%t)", lt.INTEGER.BitWidth, lt.INTEGER.IsSigned)
+	case lt.IsSetUNKNOWN():
+		return "UNKNOWN"
+	case lt.IsSetJSON():
+		return "JSON"
+	case lt.IsSetBSON():
+		return "BSON"
+	case lt.IsSetUUID():
+		return "UUID"
+	default:
+		return "none"
+	}
+}
+
+func unitName(u *parquet.TimeUnit) string {
+	switch {
+	case u == nil:
+		return "?"
+	case u.IsSetMILLIS():
+		return "MILLIS"
+	case u.IsSetMICROS():
+		return "MICROS"
+	case u.IsSetNANOS():
+		return "NANOS"
+	default:
+		return "?"
+	}
+}
+
+func requireType(elem *parquet.SchemaElement, annotation string, types ...parquet.Type) string {
+	for _, t := range types {
+		if elem.GetType() == t {
+			return ""
+		}
+	}
+
+	names := make([]string, len(types))
+	for i := range types {
+		names[i] = types[i].String()
+	}
+
+	return fmt.Sprintf("%s requires %s, not %s", annotation, strings.Join(names, " or "), elem.GetType())
+}
+
+func unitTypes(u *parquet.TimeUnit) parquet.Type {
+	if u != nil && u.IsSetMILLIS() {
+		return parquet.Type_INT32
+	}
+
+	return parquet.Type_INT64
+}
+
+func checkLogicalType(lt *parquet.LogicalType, elem *parquet.SchemaElement) string {
+	switch {
+	case lt.IsSetSTRING():
+		return requireType(elem, "STRING", parquet.Type_BYTE_ARRAY)
+	case lt.IsSetENUM():
+		return requireType(elem, "ENUM", parquet.Type_BYTE_ARRAY)
+	case lt.IsSetJSON():
+		return requireType(elem, "JSON", parquet.Type_BYTE_ARRAY)
+	case lt.IsSetBSON():
+		return requireType(elem, "BSON", parquet.Type_BYTE_ARRAY)
+	case lt.IsSetUUID():
+		if msg := requireType(elem, "UUID", parquet.Type_FIXED_LEN_BYTE_ARRAY); msg != "" {
+			return msg
+		}
+
+		if elem.GetTypeLength() != uuidSize {
+			return fmt.Sprintf("UUID requires a length of %d, not %d", uuidSize, elem.GetTypeLength())
+		}
+	case lt.IsSetDATE():
+		return requireType(elem, "DATE", parquet.Type_INT32)
+	case lt.IsSetTIME():
+		if !lt.TIME.IsSetUnit() {
+			return "TIME without unit"
+		}
+
+		return requireType(elem, logicalTypeName(lt), unitTypes(lt.TIME.GetUnit()))
+	case lt.IsSetTIMESTAMP():
+		if !lt.TIMESTAMP.IsSetUnit() {
+			return "TIMESTAMP without unit"
+		}
+
+		return requireType(elem, "TIMESTAMP", parquet.Type_INT64)
+	case lt.IsSetINTEGER():
+		switch lt.INTEGER.BitWidth {
+		case 8, 16, 32: //nolint:gomnd // bit widths
+			return requireType(elem, logicalTypeName(lt), parquet.Type_INT32)
+		case 64: //nolint:gomnd // bit width
+			return requireType(elem, logicalTypeName(lt), parquet.Type_INT64)
+		default:
+			return fmt.Sprintf("INTEGER with invalid bit width %d", lt.INTEGER.BitWidth)
+		}
+	case lt.IsSetLIST(), lt.IsSetMAP():
+		return fmt.Sprintf("logical type %s only allowed on a group", logicalTypeName(lt))
+	}
+
+	return ""
+}
+
+func checkConvertedType(elem *parquet.SchemaElement) string {
+	ct := elem.GetConvertedType()
+
+	switch ct {
+	case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON, parquet.ConvertedType_BSON:
+		return requireType(elem, ct.String(), parquet.Type_BYTE_ARRAY)
+	case parquet.ConvertedType_DATE, parquet.ConvertedType_TIME_MILLIS,
+		parquet.ConvertedType_INT_8, parquet.ConvertedType_INT_16, parquet.ConvertedType_INT_32,
+		parquet.ConvertedType_UINT_8, parquet.ConvertedType_UINT_16, parquet.ConvertedType_UINT_32:
+		return requireType(elem, ct.String(), parquet.Type_INT32)
+	case parquet.ConvertedType_TIME_MICROS, parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS,
+		parquet.ConvertedType_INT_64, parquet.ConvertedType_UINT_64:
+		return requireType(elem, ct.String(), parquet.Type_INT64)
+	case parquet.ConvertedType_INTERVAL:
+		if msg := requireType(elem, ct.String(), parquet.Type_FIXED_LEN_BYTE_ARRAY); msg != "" {
+			return msg
+		}
+
+		if elem.GetTypeLength() != 12 { //nolint:gomnd // months, days and milliseconds
+			return fmt.Sprintf("INTERVAL requires a length of 12, not %d", elem.GetTypeLength())
+		}
+	case parquet.ConvertedType_MAP, parquet.ConvertedType_MAP_KEY_VALUE, parquet.ConvertedType_LIST:
+		return fmt.Sprintf("converted type %s only allowed on a group", ct)
+	case parquet.ConvertedType_DECIMAL:
+		// checked by logical.ValidateDecimal
+	}
+
+	return ""
+}
+
+// convertedTypeOf returns the converted type equivalent to a logical type, if any.
+func convertedTypeOf(lt *parquet.LogicalType) (parquet.ConvertedType, bool) {
+	switch {
+	case lt.IsSetSTRING():
+		return parquet.ConvertedType_UTF8, true
+	case lt.IsSetENUM():
+		return parquet.ConvertedType_ENUM, true
+	case lt.IsSetJSON():
+		return parquet.ConvertedType_JSON, true
+	case lt.IsSetBSON():
+		return parquet.ConvertedType_BSON, true
+	case lt.IsSetDECIMAL():
+		return parquet.ConvertedType_DECIMAL, true
+	case lt.IsSetDATE():
+		return parquet.ConvertedType_DATE, true
+	case lt.IsSetLIST():
+		return parquet.ConvertedType_LIST, true
+	case lt.IsSetTIME() && lt.TIME.GetUnit().IsSetMILLIS():
+		return parquet.ConvertedType_TIME_MILLIS, true
+	case lt.IsSetTIME() && lt.TIME.GetUnit().IsSetMICROS():
+		return parquet.ConvertedType_TIME_MICROS, true
+	case lt.IsSetTIMESTAMP() && lt.TIMESTAMP.GetUnit().IsSetMILLIS():
+		return parquet.ConvertedType_TIMESTAMP_MILLIS, true
+	case lt.IsSetTIMESTAMP() && lt.TIMESTAMP.GetUnit().IsSetMICROS():
+		return parquet.ConvertedType_TIMESTAMP_MICROS, true
+	case lt.IsSetINTEGER():
+		return integerConvertedType(lt.INTEGER)
+	}
+
+	// MAP may be written with MAP or MAP_KEY_VALUE, the other types have no converted type.
+	return 0, false
+}
+
+func integerConvertedType(t *parquet.IntType) (parquet.ConvertedType, bool) {
+	signed := map[int8]parquet.ConvertedType{
+		8: parquet.ConvertedType_INT_8, 16: parquet.ConvertedType_INT_16,
+		32: parquet.ConvertedType_INT_32, 64: parquet.ConvertedType_INT_64,
+	}
+	unsigned := map[int8]parquet.ConvertedType{
+		8: parquet.ConvertedType_UINT_8, 16: parquet.ConvertedType_UINT_16,
+		32: parquet.ConvertedType_UINT_32, 64: parquet.ConvertedType_UINT_64,
+	}
+
+	if t.IsSigned {
+		ct, ok := signed[t.BitWidth]
+		return ct, ok
+	}
+
+	ct, ok := unsigned[t.BitWidth]
+
+	return ct, ok
+}
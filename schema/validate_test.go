@@ -0,0 +1,239 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/hexbee-net/parquet/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", TestValidate_Valid)
+	t.Run("PhysicalType", TestValidate_PhysicalType)
+	t.Run("UUID", TestValidate_UUID)
+	t.Run("Integer", TestValidate_Integer)
+	t.Run("ConvertedTypeMismatch", TestValidate_ConvertedTypeMismatch)
+	t.Run("Map", TestValidate_Map)
+	t.Run("List", TestValidate_List)
+	t.Run("Errors", TestValidate_Errors)
+	t.Run("Strict", TestValidate_Strict)
+}
+
+func validate(t *testing.T, elements ...*parquet.SchemaElement) ValidationErrors {
+	t.Helper()
+
+	elements = append([]*parquet.SchemaElement{group("root", parquet.FieldRepetitionType_REQUIRED, int32(len(elements)))}, elements...)
+
+	s, err := LoadSchema(elements)
+	require.NoError(t, err)
+
+	err = Validate(s.GetSchemaDefinition())
+	if err == nil {
+		return nil
+	}
+
+	require.IsType(t, ValidationErrors{}, err)
+
+	return err.(ValidationErrors) //nolint:errorlint // checked above
+}
+
+func fixed(name string, length int32) *parquet.SchemaElement {
+	e := leaf(name, parquet.FieldRepetitionType_REQUIRED, parquet.Type_FIXED_LEN_BYTE_ARRAY)
+	e.TypeLength = &length
+
+	return e
+}
+
+func TestValidate_Valid(t *testing.T) {
+	t.Parallel()
+
+	millis := &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}
+
+	errs := validate(t,
+		annotated(leaf("name", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_BYTE_ARRAY),
+			&parquet.LogicalType{STRING: &parquet.StringType{}}, parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)),
+		annotated(fixed("id", 16), &parquet.LogicalType{UUID: &parquet.UUIDType{}}, nil),
+		annotated(leaf("day", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{DATE: &parquet.DateType{}}, nil),
+		annotated(leaf("at", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{TIME: &parquet.TimeType{Unit: millis}}, parquet.ConvertedTypePtr(parquet.ConvertedType_TIME_MILLIS)),
+		annotated(leaf("small", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 8, IsSigned: true}}, parquet.ConvertedTypePtr(parquet.ConvertedType_INT_8)),
+		annotated(fixed("duration", 12), nil, parquet.ConvertedTypePtr(parquet.ConvertedType_INTERVAL)),
+	)
+	assert.Empty(t, errs)
+}
+
+func TestValidate_PhysicalType(t *testing.T) {
+	t.Parallel()
+
+	errs := validate(t,
+		annotated(leaf("name", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{STRING: &parquet.StringType{}}, nil),
+	)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "name", errs[0].Path)
+	assert.Equal(t, "name: STRING requires BYTE_ARRAY, not INT32", errs[0].Error())
+
+	errs = validate(t,
+		annotated(leaf("ts", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			nil, parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MILLIS)),
+	)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "ts", errs[0].Path)
+}
+
+func TestValidate_UUID(t *testing.T) {
+	t.Parallel()
+
+	errs := validate(t, annotated(fixed("id", 8), &parquet.LogicalType{UUID: &parquet.UUIDType{}}, nil))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "id: UUID requires a length of 16, not 8", errs[0].Error())
+}
+
+func TestValidate_Integer(t *testing.T) {
+	t.Parallel()
+
+	errs := validate(t,
+		annotated(leaf("a", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: true}}, nil),
+		annotated(leaf("b", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 12, IsSigned: true}}, nil),
+		annotated(leaf("c", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT64),
+			nil, parquet.ConvertedTypePtr(parquet.ConvertedType_UINT_16)),
+	)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "a", errs[0].Path)
+	assert.Equal(t, "b", errs[1].Path)
+	assert.Equal(t, "c", errs[2].Path)
+}
+
+func TestValidate_ConvertedTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	errs := validate(t,
+		annotated(leaf("n", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 16, IsSigned: false}}, parquet.ConvertedTypePtr(parquet.ConvertedType_INT_16)),
+	)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "n", errs[0].Path)
+	assert.Contains(t, errs[0].Message, "doesn't match")
+}
+
+func TestValidate_Map(t *testing.T) {
+	t.Parallel()
+
+	mapType := &parquet.LogicalType{MAP: &parquet.MapType{}}
+
+	errs := validate(t,
+		annotated(group("m", parquet.FieldRepetitionType_OPTIONAL, 1), mapType, nil),
+		group("key_value", parquet.FieldRepetitionType_REPEATED, 2),
+		leaf("key", parquet.FieldRepetitionType_REQUIRED, parquet.Type_BYTE_ARRAY),
+		leaf("value", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_INT64),
+	)
+	assert.Empty(t, errs)
+
+	// Without a repeated key_value group.
+	errs = validate(t,
+		annotated(group("m", parquet.FieldRepetitionType_OPTIONAL, 2), mapType, nil),
+		leaf("key", parquet.FieldRepetitionType_REQUIRED, parquet.Type_BYTE_ARRAY),
+		leaf("value", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_INT64),
+	)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "m", errs[0].Path)
+
+	// With an optional key.
+	errs = validate(t,
+		annotated(group("m", parquet.FieldRepetitionType_OPTIONAL, 1), mapType, nil),
+		group("key_value", parquet.FieldRepetitionType_REPEATED, 1),
+		leaf("key", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_BYTE_ARRAY),
+	)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "m.key_value.key", errs[0].Path)
+}
+
+func TestValidate_List(t *testing.T) {
+	t.Parallel()
+
+	listType := &parquet.LogicalType{LIST: &parquet.ListType{}}
+
+	errs := validate(t,
+		annotated(group("l", parquet.FieldRepetitionType_OPTIONAL, 1), listType, nil),
+		group("list", parquet.FieldRepetitionType_REPEATED, 1),
+		leaf("element", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_INT64),
+	)
+	assert.Empty(t, errs)
+
+	errs = validate(t,
+		annotated(group("l", parquet.FieldRepetitionType_REPEATED, 1), listType, nil),
+		group("list", parquet.FieldRepetitionType_OPTIONAL, 1),
+		annotated(leaf("element", parquet.FieldRepetitionType_OPTIONAL, parquet.Type_INT64), listType, nil),
+	)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "l", errs[0].Path)
+	assert.Equal(t, "l", errs[1].Path)
+	assert.Equal(t, "l.list.element", errs[2].Path)
+}
+
+func TestValidate_Errors(t *testing.T) {
+	t.Parallel()
+
+	errs := validate(t,
+		annotated(leaf("a", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT64),
+			&parquet.LogicalType{JSON: &parquet.JsonType{}}, nil),
+		annotated(leaf("b", parquet.FieldRepetitionType_REQUIRED, parquet.Type_DOUBLE),
+			nil, parquet.ConvertedTypePtr(parquet.ConvertedType_ENUM)),
+	)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "a: JSON requires BYTE_ARRAY, not INT64; b: ENUM requires BYTE_ARRAY, not DOUBLE", errs.Error())
+
+	errs = validate(t,
+		annotated(leaf("a.b", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT64),
+			&parquet.LogicalType{JSON: &parquet.JsonType{}}, nil),
+	)
+	require.Len(t, errs, 1)
+	assert.Equal(t, `a\.b`, errs[0].Path)
+
+	assert.Error(t, Validate(nil))
+	assert.Error(t, Validate(&SchemaDefinition{}))
+}
+
+func TestValidate_Strict(t *testing.T) {
+	t.Parallel()
+
+	elements := []*parquet.SchemaElement{
+		group("root", parquet.FieldRepetitionType_REQUIRED, 1),
+		annotated(leaf("name", parquet.FieldRepetitionType_REQUIRED, parquet.Type_INT32),
+			&parquet.LogicalType{STRING: &parquet.StringType{}}, nil),
+	}
+
+	_, err := LoadSchema(elements)
+	assert.NoError(t, err)
+
+	_, err = LoadSchemaStrict(elements)
+	assert.Error(t, err)
+
+	s, err := LoadSchema(elements)
+	require.NoError(t, err)
+
+	def := s.GetSchemaDefinition()
+
+	err = (&Schema{}).SetSchemaDefinitionStrict(def)
+	assert.IsType(t, ValidationErrors{}, err)
+	assert.NoError(t, (&Schema{}).SetSchemaDefinition(def))
+
+	name := def.RootColumn.Children[0]
+
+	_, err = name.CreateColumnStrict()
+	assert.EqualError(t, err, "name: STRING requires BYTE_ARRAY, not INT32")
+
+	_, err = name.CreateColumn()
+	assert.NoError(t, err)
+
+	name.SchemaElement.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+
+	_, err = name.CreateColumnStrict()
+	assert.NoError(t, err)
+	assert.NoError(t, (&Schema{}).SetSchemaDefinitionStrict(def))
+}
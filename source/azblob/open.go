@@ -0,0 +1,61 @@
+package azblob
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const errCredentials = errors.Error("unsupported credentials type")
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("azblob", open, create)
+}
+
+// open opens an azblob://account/container/blob URI.
+// The credentials option accepts an azblob.Credential, anonymous access is used by default.
+func open(ctx context.Context, uri *url.URL, options *source.Options) (source.Reader, error) {
+	credential, err := newCredential(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReader(ctx, blobURL(uri), credential, ReaderOptions{})
+}
+
+// create creates an azblob://account/container/blob URI, with the same options as open.
+func create(ctx context.Context, uri *url.URL, options *source.Options) (source.Writer, error) {
+	credential, err := newCredential(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAzBlobFileWriter(ctx, blobURL(uri), credential, WriterOptions{})
+}
+
+func newCredential(options *source.Options) (azblob.Credential, error) {
+	if options.Credentials == nil {
+		return azblob.NewAnonymousCredential(), nil
+	}
+
+	c, ok := options.Credentials.(azblob.Credential)
+	if !ok {
+		return nil, errors.WithStack(errCredentials)
+	}
+
+	return c, nil
+}
+
+func blobURL(uri *url.URL) string {
+	u := url.URL{
+		Scheme: "https",
+		Host:   uri.Host + ".blob.core.windows.net",
+		Path:   uri.Path,
+	}
+
+	return u.String()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Azure/azure-pipeline-go/pipeline"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
 )
 
 type ReaderOptions struct {
@@ -16,14 +17,19 @@ type ReaderOptions struct {
 	RetryOptions azblob.RetryOptions
 	// Log configures the pipeline's logging infrastructure indicating what information is logged and where.
 	Log pipeline.LogOptions
+	// ReadAheadSize is the size of the window buffered after each sequential read.
+	// Defaults to source.DefaultReadAheadSize, a negative value disables the read-ahead.
+	ReadAheadSize int
 }
 
 type Reader struct {
 	blob
 
-	fileSize int64
-	offset   int64
-	options  ReaderOptions
+	fileSize  int64
+	etag      azblob.ETag
+	offset    int64
+	options   ReaderOptions
+	readAhead *source.ReadAhead
 }
 
 // NewReader creates an Azure Blob Reader.
@@ -37,6 +43,12 @@ func NewReader(ctx context.Context, URL string, credential azblob.Credential, op
 		options:  options,
 	}
 
+	if options.ReadAheadSize == 0 {
+		options.ReadAheadSize = source.DefaultReadAheadSize
+	}
+
+	r.readAhead = source.NewReadAhead(options.ReadAheadSize)
+
 	if err := r.blob.open(URL, options.HTTPSender, options.RetryOptions, options.Log); err != nil {
 		return nil, err
 	}
@@ -45,46 +57,104 @@ func NewReader(ctx context.Context, URL string, credential azblob.Credential, op
 		return nil, errors.Wrap(err, "failed to get blob properties")
 	} else {
 		r.fileSize = props.ContentLength()
+		r.etag = props.ETag()
 	}
 
 	return r, nil
 }
 
-func (r Reader) Read(p []byte) (n int, err error) {
+func (r *Reader) Read(p []byte) (n int, err error) {
+	return r.ReadContext(r.ctx, p)
+}
+
+// ReadContext is the same as Read but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	if r.offset >= r.fileSize {
+		return 0, io.EOF
+	}
+
+	n, err = r.readAhead.ReadAt(ctx, p, r.offset, r.ReadAtContext)
+	r.offset += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.ReadAtContext(r.ctx, p, off)
+}
+
+// ReadAtContext is the same as ReadAt but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *Reader) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
 	if r.blockBlobURL == nil {
 		return 0, errors.WithStack(errURLNotOpened)
 	}
 
-	if r.fileSize > 0 && r.offset >= r.fileSize {
+	if off < 0 {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset": off,
+			})
+	}
+
+	if off >= r.fileSize {
 		return 0, io.EOF
 	}
 
 	count := int64(len(p))
-	resp, err := r.blockBlobURL.Download(r.ctx, r.offset, count, azblob.BlobAccessConditions{}, false)
-	if err != nil {
-		return 0, err
+
+	// The reads are pinned to the ETag of the blob returned by Identity.
+	conditions := azblob.BlobAccessConditions{
+		ModifiedAccessConditions: azblob.ModifiedAccessConditions{IfMatch: r.etag},
 	}
-	if r.fileSize < 0 {
-		r.fileSize = resp.ContentLength()
+
+	resp, err := r.blockBlobURL.Download(ctx, off, count, conditions, false)
+	if e, ok := errors.Cause(err).(azblob.StorageError); ok && e.ServiceCode() == azblob.ServiceCodeConditionNotMet {
+		return 0, errors.WithFields(
+			errors.WithStack(source.ErrObjectChanged),
+			errors.Fields{
+				"etag": r.etag,
+			})
+	} else if err != nil {
+		return 0, err
 	}
 
-	toRead := r.fileSize - r.offset
+	// The size is fetched once when the reader is opened: ReadAtContext may be
+	// called concurrently.
+	toRead := r.fileSize - off
 	if toRead > count {
 		toRead = count
+	} else if toRead < 0 {
+		toRead = 0
 	}
 
 	body := resp.Body(azblob.RetryReaderOptions{})
-	bytesRead, err := io.ReadFull(body, p[:toRead])
+	defer func() { _ = body.Close() }()
+
+	n, err = io.ReadFull(body, p[:toRead])
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to read data")
+		return n, errors.Wrap(err, "failed to read data")
 	}
 
-	r.offset += int64(bytesRead)
+	if int64(n) < count {
+		return n, io.EOF
+	}
 
-	return bytesRead, nil
+	return n, nil
 }
 
-func (r Reader) Seek(offset int64, whence int) (int64, error) {
+// Size returns the size of the blob.
+func (r *Reader) Size() int64 {
+	return r.fileSize
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	if whence < io.SeekStart || whence > io.SeekEnd {
 		return 0, errors.WithFields(
 			errors.WithStack(errWhence),
@@ -126,14 +196,32 @@ func (r Reader) Seek(offset int64, whence int) (int64, error) {
 						"fileSize": r.fileSize,
 					})
 			}
+
+			offset += r.fileSize
 		}
 	}
 
 	r.offset = offset
 
+	if !r.readAhead.Contains(r.offset) {
+		r.readAhead.Invalidate()
+	}
+
 	return r.offset, nil
 }
 
-func (r Reader) Close() error {
+// SetReadAhead sets the size of the window buffered after each sequential read.
+// Reads smaller than the window are served from it without issuing a request.
+// A size of 0 disables the read-ahead.
+func (r *Reader) SetReadAhead(size int) {
+	r.readAhead = source.NewReadAhead(size)
+}
+
+// Identity returns the URL of the blob and its ETag.
+func (r *Reader) Identity() (id, version string) {
+	return r.URL.String(), string(r.etag)
+}
+
+func (r *Reader) Close() error {
 	return nil
 }
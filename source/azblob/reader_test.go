@@ -0,0 +1,72 @@
+package azblob
+
+import (
+	"io"
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Run("Seek", TestReader_Seek)
+	t.Run("EmptyBlob", TestReader_EmptyBlob)
+}
+
+func TestReader_Seek(t *testing.T) {
+	t.Parallel()
+
+	r := &Reader{fileSize: 100}
+
+	for _, tt := range []struct {
+		offset   int64
+		whence   int
+		expected int64
+	}{
+		{offset: 10, whence: io.SeekStart, expected: 10},
+		{offset: 5, whence: io.SeekCurrent, expected: 15},
+		{offset: -8, whence: io.SeekEnd, expected: 92},
+		{offset: -100, whence: io.SeekEnd, expected: 0},
+		{offset: 100, whence: io.SeekStart, expected: 100},
+	} {
+		pos, err := r.Seek(tt.offset, tt.whence)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, pos)
+		assert.Equal(t, tt.expected, r.offset)
+	}
+
+	for _, tt := range []struct {
+		offset int64
+		whence int
+	}{
+		{offset: -1, whence: io.SeekStart},
+		{offset: 101, whence: io.SeekStart},
+		{offset: 1, whence: io.SeekCurrent},
+		{offset: 1, whence: io.SeekEnd},
+		{offset: -101, whence: io.SeekEnd},
+		{offset: 0, whence: 3},
+	} {
+		_, err := r.Seek(tt.offset, tt.whence)
+		assert.Error(t, err, "offset %d whence %d", tt.offset, tt.whence)
+	}
+}
+
+func TestReader_EmptyBlob(t *testing.T) {
+	t.Parallel()
+
+	// The blob URL has no pipeline: any request would fail.
+	r := &Reader{blob: blob{blockBlobURL: &azblob.BlockBlobURL{}}}
+
+	p := make([]byte, 4)
+
+	for _, off := range []int64{0, 1, 10} {
+		n, err := r.ReadAt(p, off)
+		assert.Equal(t, 0, n)
+		assert.Equal(t, io.EOF, err, "offset %d", off)
+	}
+
+	n, err := r.Read(p)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+}
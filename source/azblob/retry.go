@@ -0,0 +1,34 @@
+package azblob
+
+import (
+	"net/http"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/hexbee-net/errors"
+)
+
+// IsRetryable returns true if err is a transient Azure Blob error: a 408, 429
+// or 5xx response, or an error the SDK reports as temporary.
+func IsRetryable(err error) bool {
+	e, ok := errors.Cause(err).(azblob.StorageError)
+	if !ok {
+		return false
+	}
+
+	if resp := e.Response(); resp != nil {
+		switch {
+		case resp.StatusCode == http.StatusRequestTimeout,
+			resp.StatusCode == http.StatusTooManyRequests,
+			resp.StatusCode >= http.StatusInternalServerError:
+			return true
+		}
+	}
+
+	return e.Temporary()
+}
+
+// IsRetryable returns true if err is a transient Azure Blob error.
+// It makes the Reader a retry.Classifier.
+func (r *Reader) IsRetryable(err error) bool {
+	return IsRetryable(err)
+}
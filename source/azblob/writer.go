@@ -9,6 +9,8 @@ import (
 	"github.com/hexbee-net/errors"
 )
 
+const errAborted = errors.Error("upload aborted")
+
 type WriterOptions struct {
 	// HTTPSender configures the sender of HTTP requests
 	HTTPSender pipeline.Factory
@@ -18,64 +20,91 @@ type WriterOptions struct {
 	Log pipeline.LogOptions
 	// Parallelism limits the number of go routines created to read blob content (0 = default)
 	Parallelism int
+	// BlockSize is the size of the staged blocks. Defaults to 1MiB, the minimum.
+	BlockSize int
+	// HTTPHeaders are the HTTP headers of the blob, e.g. its content type.
+	HTTPHeaders azblob.BlobHTTPHeaders
+	// Metadata is stored with the blob.
+	Metadata azblob.Metadata
 }
 
 type Writer struct {
 	blob
 
+	cancel     context.CancelFunc
 	writeDone  chan error
 	pipeReader *io.PipeReader
 	pipeWriter *io.PipeWriter
 	options    WriterOptions
+	aborted    bool
 }
 
 // NewAzBlobFileWriter creates an Azure Blob FileWriter, to be used with NewParquetWriter
 func NewAzBlobFileWriter(ctx context.Context, URL string, credential azblob.Credential, options WriterOptions) (w *Writer, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+
 	w = &Writer{
 		blob: blob{
 			ctx:        ctx,
 			credential: credential,
 		},
-		writeDone: make(chan error),
+		cancel:    cancel,
+		writeDone: make(chan error, 1),
 		options:   options,
 	}
 
 	if err := w.blob.open(URL, options.HTTPSender, options.RetryOptions, options.Log); err != nil {
+		cancel()
 		return nil, err
 	}
 
 	w.pipeReader, w.pipeWriter = io.Pipe()
 
-	go func(ctx context.Context, blobURL *azblob.BlockBlobURL, opt WriterOptions, reader io.Reader, readerPipeSource *io.PipeWriter, done chan<- error) {
+	go func(ctx context.Context, blobURL *azblob.BlockBlobURL, opt WriterOptions, reader *io.PipeReader, done chan<- error) {
 		defer close(done)
 
-		// upload data and signal done when complete
-		_, err := azblob.UploadStreamToBlockBlob(ctx, reader, *blobURL, azblob.UploadStreamToBlockBlobOptions{MaxBuffers: opt.Parallelism})
+		// upload data and signal done when complete.
+		// The block list is only committed once all the data is staged, so a
+		// failed upload never creates the blob.
+		_, err := azblob.UploadStreamToBlockBlob(ctx, reader, *blobURL, azblob.UploadStreamToBlockBlobOptions{
+			BufferSize:      opt.BlockSize,
+			MaxBuffers:      opt.Parallelism,
+			BlobHTTPHeaders: opt.HTTPHeaders,
+			Metadata:        opt.Metadata,
+		})
 		if err != nil {
-			_ = readerPipeSource.CloseWithError(err)
+			_ = reader.CloseWithError(err)
 		}
 
 		done <- err
-	}(w.ctx, w.blockBlobURL, w.options, w.pipeReader, w.pipeWriter, w.writeDone)
+	}(w.ctx, w.blockBlobURL, w.options, w.pipeReader, w.writeDone)
 
 	return w, nil
 }
 
-func (w Writer) Write(p []byte) (n int, err error) {
+func (w *Writer) Write(p []byte) (n int, err error) {
 	if w.blockBlobURL == nil {
 		return 0, errors.WithStack(errURLNotOpened)
 	}
 
+	if w.aborted {
+		return 0, errors.WithStack(errAborted)
+	}
+
 	bytesWritten, writeError := w.pipeWriter.Write(p)
 	if writeError != nil {
-		_ = w.pipeWriter.CloseWithError(err)
+		_ = w.pipeWriter.CloseWithError(writeError)
 		return 0, writeError
 	}
 
 	return bytesWritten, nil
 }
 
-func (w Writer) Close() (err error) {
+func (w *Writer) Close() (err error) {
+	if w.aborted {
+		return errors.WithStack(errAborted)
+	}
+
 	if w.pipeWriter != nil {
 		if closeErr := w.pipeWriter.Close(); closeErr != nil {
 			return errors.Wrap(closeErr, "failed to close pipe writer")
@@ -87,5 +116,34 @@ func (w Writer) Close() (err error) {
 		}
 	}
 
+	w.cancel()
+
 	return err
 }
+
+// Abort cancels the upload before the block list is committed, so that no blob
+// is created. The staged blocks are garbage collected by Azure.
+// err is the cause reported to the uploader, it defaults to an "upload aborted" error.
+func (w *Writer) Abort(err error) error {
+	if w.aborted {
+		return nil
+	}
+
+	w.aborted = true
+
+	if err == nil {
+		err = errAborted
+	}
+
+	if w.pipeWriter != nil {
+		_ = w.pipeWriter.CloseWithError(err)
+	}
+
+	w.cancel()
+
+	if w.writeDone != nil {
+		<-w.writeDone
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const (
+	// DefaultBlockSize is the default size of the blocks fetched from the sources.
+	DefaultBlockSize = 1024 * 1024
+	// DefaultMemorySize is the default capacity of the in-memory data block tier.
+	DefaultMemorySize = 64 * 1024 * 1024
+	// DefaultMetadataSize is the default capacity of the in-memory footer and dictionary page tier.
+	DefaultMetadataSize = 16 * 1024 * 1024
+	// DefaultDiskSize is the default capacity of the on-disk tier.
+	DefaultDiskSize = 1024 * 1024 * 1024
+)
+
+const errNoIdentity = errors.Error("source does not provide an identity")
+
+// Key identifies a cached object and its version (ETag, generation...).
+// Cached data is only shared between readers with the same key.
+type Key struct {
+	ID      string
+	Version string
+}
+
+// Options contains the configuration of a Cache.
+type Options struct {
+	// BlockSize is the size of the blocks fetched from the sources and stored in the cache.
+	BlockSize int64
+	// MemorySize is the capacity, in bytes, of the in-memory data block tier.
+	MemorySize int64
+	// MetadataSize is the capacity, in bytes, of the in-memory tier dedicated to
+	// footers and dictionary pages, so that they are not evicted by data blocks.
+	MetadataSize int64
+	// DiskDir enables the on-disk tier when not empty. Blocks evicted from
+	// memory can then be served from this directory.
+	DiskDir string
+	// DiskSize is the capacity, in bytes, of the on-disk tier.
+	DiskSize int64
+}
+
+// Stats contains the hit and miss counters of a Cache.
+type Stats struct {
+	MemoryHits     int64
+	DiskHits       int64
+	Misses         int64
+	MetadataHits   int64
+	MetadataMisses int64
+}
+
+// Cache is a fixed-size block cache that can be shared by several wrapped sources.
+type Cache struct {
+	blockSize int64
+
+	mu       sync.Mutex
+	blocks   *lru
+	metadata *lru
+	disk     *diskStore
+
+	memoryHits     int64
+	diskHits       int64
+	misses         int64
+	metadataHits   int64
+	metadataMisses int64
+}
+
+// New creates a new Cache. Zero values in opts are replaced by the defaults.
+func New(opts Options) (*Cache, error) {
+	if opts.BlockSize <= 0 {
+		opts.BlockSize = DefaultBlockSize
+	}
+
+	if opts.MemorySize <= 0 {
+		opts.MemorySize = DefaultMemorySize
+	}
+
+	if opts.MetadataSize <= 0 {
+		opts.MetadataSize = DefaultMetadataSize
+	}
+
+	if opts.DiskSize <= 0 {
+		opts.DiskSize = DefaultDiskSize
+	}
+
+	c := &Cache{
+		blockSize: opts.BlockSize,
+		blocks:    newLRU(opts.MemorySize),
+		metadata:  newLRU(opts.MetadataSize),
+	}
+
+	if opts.DiskDir != "" {
+		disk, err := newDiskStore(opts.DiskDir, opts.DiskSize)
+		if err != nil {
+			return nil, err
+		}
+
+		c.disk = disk
+	}
+
+	return c, nil
+}
+
+// Wrap returns a Reader serving r through the cache.
+// The source must implement source.Identifier so that it can be keyed.
+func (c *Cache) Wrap(r source.Reader) (*Reader, error) {
+	id, ok := r.(source.Identifier)
+	if !ok {
+		return nil, errors.WithStack(errNoIdentity)
+	}
+
+	name, version := id.Identity()
+	if name == "" {
+		return nil, errors.WithStack(errNoIdentity)
+	}
+
+	return c.WrapWithKey(r, Key{ID: name, Version: version})
+}
+
+// WrapWithKey is the same as Wrap but uses the provided key to identify the source.
+func (c *Cache) WrapWithKey(r source.Reader, key Key) (*Reader, error) {
+	ra, err := source.NewReaderAt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		cache: c,
+		key:   key,
+		inner: r,
+		src:   ra,
+		size:  ra.Size(),
+	}, nil
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *Cache) Stats() Stats {
+	return Stats{
+		MemoryHits:     atomic.LoadInt64(&c.memoryHits),
+		DiskHits:       atomic.LoadInt64(&c.diskHits),
+		Misses:         atomic.LoadInt64(&c.misses),
+		MetadataHits:   atomic.LoadInt64(&c.metadataHits),
+		MetadataMisses: atomic.LoadInt64(&c.metadataMisses),
+	}
+}
+
+func (c *Cache) getBlock(key Key, index int64) ([]byte, bool) {
+	name := blockName(key, index, c.blockSize)
+
+	c.mu.Lock()
+	data, ok := c.blocks.get(name)
+	c.mu.Unlock()
+
+	if ok {
+		atomic.AddInt64(&c.memoryHits, 1)
+		return data, true
+	}
+
+	if c.disk != nil {
+		if data, ok := c.disk.get(name); ok {
+			atomic.AddInt64(&c.diskHits, 1)
+
+			c.mu.Lock()
+			c.blocks.put(name, data)
+			c.mu.Unlock()
+
+			return data, true
+		}
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+
+	return nil, false
+}
+
+func (c *Cache) putBlock(key Key, index int64, data []byte) {
+	name := blockName(key, index, c.blockSize)
+
+	c.mu.Lock()
+	c.blocks.put(name, data)
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		c.disk.put(name, data)
+	}
+}
+
+func (c *Cache) getMetadata(key Key, kind source.RangeKind, offset int64) ([]byte, bool) {
+	name := metadataName(key, kind, offset)
+
+	c.mu.Lock()
+	data, ok := c.metadata.get(name)
+	c.mu.Unlock()
+
+	if ok {
+		atomic.AddInt64(&c.metadataHits, 1)
+	} else {
+		atomic.AddInt64(&c.metadataMisses, 1)
+	}
+
+	return data, ok
+}
+
+func (c *Cache) putMetadata(key Key, kind source.RangeKind, offset int64, data []byte) {
+	name := metadataName(key, kind, offset)
+
+	c.mu.Lock()
+	c.metadata.put(name, data)
+	c.mu.Unlock()
+}
+
+func blockName(key Key, index, blockSize int64) string {
+	return key.ID + "\x00" + key.Version + "\x00" + strconv.FormatInt(blockSize, 10) + "\x00" + strconv.FormatInt(index, 10)
+}
+
+func metadataName(key Key, kind source.RangeKind, offset int64) string {
+	return key.ID + "\x00" + key.Version + "\x00" + strconv.Itoa(int(kind)) + "\x00" + strconv.FormatInt(offset, 10)
+}
+
+// /////////////////////////////////////////////////////////////////////////////
+
+// lru is a size-bounded least recently used byte slice store.
+// It is not safe for concurrent use.
+type lru struct {
+	capacity int64
+	size     int64
+	entries  *list.List
+	index    map[string]*list.Element
+}
+
+type lruEntry struct {
+	name string
+	data []byte
+}
+
+func newLRU(capacity int64) *lru {
+	return &lru{
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+func (l *lru) get(name string) ([]byte, bool) {
+	e, ok := l.index[name]
+	if !ok {
+		return nil, false
+	}
+
+	l.entries.MoveToFront(e)
+
+	return e.Value.(*lruEntry).data, true
+}
+
+func (l *lru) put(name string, data []byte) {
+	if int64(len(data)) > l.capacity {
+		return
+	}
+
+	if e, ok := l.index[name]; ok {
+		l.size += int64(len(data)) - int64(len(e.Value.(*lruEntry).data))
+		e.Value.(*lruEntry).data = data
+		l.entries.MoveToFront(e)
+	} else {
+		l.index[name] = l.entries.PushFront(&lruEntry{name: name, data: data})
+		l.size += int64(len(data))
+	}
+
+	for l.size > l.capacity {
+		e := l.entries.Back()
+		entry := e.Value.(*lruEntry)
+
+		l.entries.Remove(e)
+		delete(l.index, entry.name)
+		l.size -= int64(len(entry.data))
+	}
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hexbee-net/parquet/source"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Run("ReadAt", TestReader_ReadAt)
+	t.Run("ReadAt_EOF", TestReader_ReadAt_EOF)
+	t.Run("MemoryEviction", TestReader_MemoryEviction)
+	t.Run("DiskTier", TestReader_DiskTier)
+	t.Run("HintRange", TestReader_HintRange)
+}
+
+func testData() []byte {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	return data
+}
+
+func TestReader_ReadAt(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Options{BlockSize: 16})
+	require.NoError(t, err)
+
+	r, err := c.WrapWithKey(memory.NewReader(testData()), Key{ID: "mem://data"})
+	require.NoError(t, err)
+
+	p := make([]byte, 20)
+	n, err := r.ReadAt(p, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+	assert.Equal(t, testData()[10:30], p)
+
+	_, err = r.ReadAt(p, 12)
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{MemoryHits: 2, Misses: 2}, c.Stats())
+}
+
+func TestReader_ReadAt_EOF(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Options{BlockSize: 16})
+	require.NoError(t, err)
+
+	r, err := c.WrapWithKey(memory.NewReader(testData()), Key{ID: "mem://data"})
+	require.NoError(t, err)
+
+	p := make([]byte, 20)
+	n, err := r.ReadAt(p, 90)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, testData()[90:], p[:n])
+}
+
+func TestReader_MemoryEviction(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Options{BlockSize: 16, MemorySize: 32})
+	require.NoError(t, err)
+
+	r, err := c.WrapWithKey(memory.NewReader(testData()), Key{ID: "mem://data"})
+	require.NoError(t, err)
+
+	p := make([]byte, 48)
+	_, err = r.ReadAt(p, 0)
+	require.NoError(t, err)
+
+	_, err = r.ReadAt(p[:1], 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{Misses: 4}, c.Stats())
+}
+
+func TestReader_DiskTier(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "parquet-cache")
+	require.NoError(t, err)
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	c, err := New(Options{BlockSize: 16, MemorySize: 16, DiskDir: dir})
+	require.NoError(t, err)
+
+	r, err := c.WrapWithKey(memory.NewReader(testData()), Key{ID: "mem://data", Version: "1"})
+	require.NoError(t, err)
+
+	p := make([]byte, 32)
+	_, err = r.ReadAt(p, 0)
+	require.NoError(t, err)
+
+	_, err = r.ReadAt(p[:1], 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{DiskHits: 1, Misses: 2}, c.Stats())
+
+	// A new cache on the same directory serves the blocks written by the previous one.
+	c2, err := New(Options{BlockSize: 16, DiskDir: dir})
+	require.NoError(t, err)
+
+	r2, err := c2.WrapWithKey(memory.NewReader(testData()), Key{ID: "mem://data", Version: "1"})
+	require.NoError(t, err)
+
+	_, err = r2.ReadAt(p, 0)
+	require.NoError(t, err)
+	assert.Equal(t, testData()[:32], p)
+	assert.Equal(t, Stats{DiskHits: 2}, c2.Stats())
+}
+
+func TestReader_HintRange(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Options{BlockSize: 16})
+	require.NoError(t, err)
+
+	r, err := c.WrapWithKey(memory.NewReader(testData()), Key{ID: "mem://data"})
+	require.NoError(t, err)
+
+	r.HintRange(source.RangeFooter, 80, 20)
+
+	_, err = r.Seek(-8, io.SeekEnd)
+	require.NoError(t, err)
+
+	p := make([]byte, 8)
+	_, err = io.ReadFull(r, p)
+	require.NoError(t, err)
+	assert.Equal(t, testData()[92:], p)
+
+	_, err = r.ReadAt(p, 80)
+	require.NoError(t, err)
+	assert.Equal(t, testData()[80:88], p)
+
+	assert.Equal(t, Stats{MetadataHits: 1, MetadataMisses: 1}, c.Stats())
+}
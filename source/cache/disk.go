@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hexbee-net/errors"
+)
+
+const (
+	blockFileExt  = ".blk"
+	dirPermission = 0o700
+)
+
+// diskStore is the on-disk tier of the cache. Each block is stored in its own file,
+// named after the hash of the block name. Files are evicted in least recently used order.
+type diskStore struct {
+	dir      string
+	capacity int64
+
+	mu      sync.Mutex
+	size    int64
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type diskEntry struct {
+	file string
+	size int64
+}
+
+func newDiskStore(dir string, capacity int64) (*diskStore, error) {
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		return nil, errors.Wrap(err, "failed to create cache directory")
+	}
+
+	s := &diskStore{
+		dir:      dir,
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+	}
+
+	// Blocks stored by a previous process are still valid, since their names contain the object version.
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cache directory")
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), blockFileExt) {
+			continue
+		}
+
+		s.index[info.Name()] = s.entries.PushFront(&diskEntry{file: info.Name(), size: info.Size()})
+		s.size += info.Size()
+	}
+
+	s.mu.Lock()
+	s.evict()
+	s.mu.Unlock()
+
+	return s, nil
+}
+
+func (s *diskStore) get(name string) ([]byte, bool) {
+	file := diskFileName(name)
+
+	s.mu.Lock()
+	e, ok := s.index[file]
+
+	if ok {
+		s.entries.MoveToFront(e)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, file))
+	if err != nil {
+		s.remove(file)
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (s *diskStore) put(name string, data []byte) {
+	if int64(len(data)) > s.capacity {
+		return
+	}
+
+	file := diskFileName(name)
+
+	// Write to a temporary file first, so that concurrent readers never see partial blocks.
+	tmp, err := ioutil.TempFile(s.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(s.dir, file))
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.index[file]; ok {
+		s.size -= e.Value.(*diskEntry).size
+		s.entries.Remove(e)
+	}
+
+	s.index[file] = s.entries.PushFront(&diskEntry{file: file, size: int64(len(data))})
+	s.size += int64(len(data))
+
+	s.evict()
+}
+
+func (s *diskStore) remove(file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.index[file]; ok {
+		s.size -= e.Value.(*diskEntry).size
+		s.entries.Remove(e)
+		delete(s.index, file)
+	}
+}
+
+// evict removes the least recently used files until the store fits its capacity.
+// The caller must hold the lock.
+func (s *diskStore) evict() {
+	for s.size > s.capacity {
+		e := s.entries.Back()
+		entry := e.Value.(*diskEntry)
+
+		s.entries.Remove(e)
+		delete(s.index, entry.file)
+		s.size -= entry.size
+
+		_ = os.Remove(filepath.Join(s.dir, entry.file))
+	}
+}
+
+func diskFileName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:]) + blockFileExt
+}
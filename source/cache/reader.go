@@ -0,0 +1,231 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const (
+	errWhence        = errors.Error("invalid whence")
+	errInvalidOffset = errors.Error("invalid offset")
+)
+
+// Reader serves the content of a source through a Cache.
+// Reads are split in fixed-size blocks, which are fetched from the source only
+// when they are not available in the memory or disk tiers.
+// Ranges hinted as footer or dictionary page are stored in their own tier.
+type Reader struct {
+	cache  *Cache
+	key    Key
+	inner  source.Reader
+	src    source.ReaderAt
+	size   int64
+	offset int64
+
+	mu    sync.Mutex
+	hints []rangeHint
+}
+
+type rangeHint struct {
+	kind   source.RangeKind
+	offset int64
+	length int64
+}
+
+func (r *Reader) Read(p []byte) (n int, err error) {
+	return r.ReadContext(context.Background(), p)
+}
+
+// ReadContext is the same as Read but uses the provided context for the
+// requests issued to the source on cache misses.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	n, err = r.ReadAtContext(ctx, p, r.offset)
+	r.offset += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.ReadAtContext(context.Background(), p, off)
+}
+
+// ReadAtContext is the same as ReadAt but uses the provided context for the
+// requests issued to the source on cache misses.
+func (r *Reader) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset": off,
+			})
+	}
+
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	want := p
+	if rem := r.size - off; int64(len(want)) > rem {
+		want = want[:rem]
+	}
+
+	if h, ok := r.findHint(off, int64(len(want))); ok {
+		n, err = r.readHinted(ctx, want, off, h)
+	} else {
+		n, err = r.readBlocks(ctx, want, off)
+	}
+
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.WithFields(
+			errors.WithStack(errWhence),
+			errors.Fields{
+				"whence": whence,
+			})
+	}
+
+	if offset < 0 || offset > r.size {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset":   offset,
+				"fileSize": r.size,
+			})
+	}
+
+	r.offset = offset
+
+	return r.offset, nil
+}
+
+// Size returns the size of the underlying source.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// Identity returns the key used to store the source data in the cache.
+func (r *Reader) Identity() (id, version string) {
+	return r.key.ID, r.key.Version
+}
+
+// HintRange registers a range holding a footer or a dictionary page.
+// Reads inside such a range are served from the metadata tier of the cache.
+func (r *Reader) HintRange(kind source.RangeKind, offset, length int64) {
+	if length <= 0 || (kind != source.RangeFooter && kind != source.RangeDictionaryPage) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, h := range r.hints {
+		if h.offset == offset && h.length == length {
+			return
+		}
+	}
+
+	r.hints = append(r.hints, rangeHint{kind: kind, offset: offset, length: length})
+}
+
+func (r *Reader) Close() error {
+	return r.inner.Close()
+}
+
+func (r *Reader) findHint(off, length int64) (rangeHint, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, h := range r.hints {
+		if off >= h.offset && off+length <= h.offset+h.length {
+			return h, true
+		}
+	}
+
+	return rangeHint{}, false
+}
+
+func (r *Reader) readHinted(ctx context.Context, p []byte, off int64, h rangeHint) (int, error) {
+	data, ok := r.cache.getMetadata(r.key, h.kind, h.offset)
+	if !ok || int64(len(data)) != h.length {
+		data = make([]byte, h.length)
+
+		n, err := source.ReadAtContext(ctx, r.src, data, h.offset)
+		if err != nil && !(err == io.EOF && n == len(data)) {
+			return 0, errors.Wrap(err, "failed to read source data")
+		}
+
+		r.cache.putMetadata(r.key, h.kind, h.offset, data)
+	}
+
+	return copy(p, data[off-h.offset:]), nil
+}
+
+func (r *Reader) readBlocks(ctx context.Context, p []byte, off int64) (n int, err error) {
+	blockSize := r.cache.blockSize
+
+	for n < len(p) {
+		index := off / blockSize
+
+		data, err := r.block(ctx, index)
+		if err != nil {
+			return n, err
+		}
+
+		start := off - index*blockSize
+		if start >= int64(len(data)) {
+			return n, io.EOF
+		}
+
+		c := copy(p[n:], data[start:])
+		n += c
+		off += int64(c)
+	}
+
+	return n, nil
+}
+
+func (r *Reader) block(ctx context.Context, index int64) ([]byte, error) {
+	if data, ok := r.cache.getBlock(r.key, index); ok {
+		return data, nil
+	}
+
+	blockSize := r.cache.blockSize
+	start := index * blockSize
+
+	length := blockSize
+	if rem := r.size - start; rem < length {
+		length = rem
+	}
+
+	data := make([]byte, length)
+
+	n, err := source.ReadAtContext(ctx, r.src, data, start)
+	if err != nil && !(err == io.EOF && n == len(data)) {
+		return nil, errors.Wrap(err, "failed to read source data")
+	}
+
+	r.cache.putBlock(r.key, index, data)
+
+	return data, nil
+}
@@ -0,0 +1,22 @@
+package source
+
+import "context"
+
+type columnPathKey struct{}
+
+// WithColumnPath returns a copy of ctx carrying the path of the column whose
+// data is read with it, so that wrappers can attribute the reads to the column.
+func WithColumnPath(ctx context.Context, path []string) context.Context {
+	return context.WithValue(ctx, columnPathKey{}, path)
+}
+
+// ColumnPath returns the column path carried by ctx, or nil.
+func ColumnPath(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+
+	path, _ := ctx.Value(columnPathKey{}).([]string)
+
+	return path
+}
@@ -0,0 +1,92 @@
+package gcs
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+	"google.golang.org/api/option"
+)
+
+const (
+	errCredentials = errors.Error("unsupported credentials type")
+
+	// ProjectOption is the key of the option holding the GCP project ID.
+	ProjectOption = "project"
+)
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("gs", open, create)
+}
+
+// open opens a gs://bucket/path URI.
+// The client option accepts a *storage.Client and the credentials option
+// an option.ClientOption, e.g. option.WithCredentialsFile.
+func open(ctx context.Context, uri *url.URL, options *source.Options) (source.Reader, error) {
+	client, external, err := newClient(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := NewReaderWithClient(ctx, client, options.StringValue(ProjectOption), uri.Host, strings.TrimPrefix(uri.Path, "/"))
+	if err != nil {
+		if !external {
+			_ = client.Close()
+		}
+
+		return nil, err
+	}
+
+	r.externalClient = external
+
+	return r, nil
+}
+
+// create creates a gs://bucket/path URI, with the same options as open.
+func create(ctx context.Context, uri *url.URL, options *source.Options) (source.Writer, error) {
+	client, external, err := newClient(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewWriterWithClient(ctx, client, options.StringValue(ProjectOption), uri.Host, strings.TrimPrefix(uri.Path, "/"))
+	if err != nil {
+		if !external {
+			_ = client.Close()
+		}
+
+		return nil, err
+	}
+
+	w.externalClient = external
+
+	return w, nil
+}
+
+func newClient(ctx context.Context, options *source.Options) (client *storage.Client, external bool, err error) {
+	if c, ok := options.Client.(*storage.Client); ok {
+		return c, true, nil
+	}
+
+	var opts []option.ClientOption
+
+	if options.Credentials != nil {
+		opt, ok := options.Credentials.(option.ClientOption)
+		if !ok {
+			return nil, false, errors.WithStack(errCredentials)
+		}
+
+		opts = append(opts, opt)
+	}
+
+	client, err = storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, false, errors.Wrap(err, errInstantiate.Error())
+	}
+
+	return client, false, nil
+}
@@ -3,16 +3,20 @@ package gcs
 import (
 	"context"
 	"io"
+	"strconv"
 
 	"cloud.google.com/go/storage"
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
 )
 
 type Reader struct {
 	file
-	fileSize int64
-	offset   int64
-	whence   int
+	fileSize   int64
+	generation int64
+	offset     int64
+	whence     int
+	readAhead  *source.ReadAhead
 }
 
 // NewReader creates a GCS Reader.
@@ -31,6 +35,7 @@ func NewReader(ctx context.Context, projectId, bucketName, name string) (*Reader
 			externalClient: false,
 			Client:         client,
 		},
+		readAhead: source.NewReadAhead(source.DefaultReadAheadSize),
 	}
 
 	if err := reader.open(ctx); err != nil {
@@ -51,6 +56,7 @@ func NewReaderWithClient(ctx context.Context, client *storage.Client, projectID,
 			externalClient: true,
 			Client:         client,
 		},
+		readAhead: source.NewReadAhead(source.DefaultReadAheadSize),
 	}
 
 	if err := reader.open(ctx); err != nil {
@@ -61,29 +67,74 @@ func NewReaderWithClient(ctx context.Context, client *storage.Client, projectID,
 }
 
 func (r *Reader) Read(p []byte) (cnt int, err error) {
+	return r.ReadContext(r.ctx, p)
+}
+
+// ReadContext is the same as Read but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (cnt int, err error) {
 	if r.fileSize > 0 && r.offset >= r.fileSize {
 		return 0, io.EOF
 	}
 
-	numBytes := len(p)
-	reader, err := r.Object.NewRangeReader(r.ctx, r.offset, int64(numBytes))
-	if err != nil {
+	cnt, err = r.readAhead.ReadAt(ctx, p, r.offset, r.ReadAtContext)
+	r.offset += int64(cnt)
+
+	if err == io.EOF && cnt > 0 {
+		err = nil
+	}
+
+	return cnt, err
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (cnt int, err error) {
+	return r.ReadAtContext(r.ctx, p, off)
+}
+
+// ReadAtContext is the same as ReadAt but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *Reader) ReadAtContext(ctx context.Context, p []byte, off int64) (cnt int, err error) {
+	if off < 0 {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset": off,
+			})
+	}
+
+	if off >= r.fileSize {
+		return 0, io.EOF
+	}
+
+	reader, err := r.Object.NewRangeReader(ctx, off, int64(len(p)))
+	if err == storage.ErrObjectNotExist {
+		return 0, errors.WithFields(
+			errors.WithStack(source.ErrObjectChanged),
+			errors.Fields{
+				"generation": r.generation,
+			})
+	} else if err != nil {
+		return 0, errors.Wrap(err, "failed to open object range reader")
 	}
 	defer func() { _ = reader.Close() }()
 
-	for cnt < numBytes {
-		n, err := reader.Read(p[cnt:])
-		cnt += n
+	cnt, err = io.ReadFull(reader, p)
 
-		if err != nil {
-			return cnt, errors.Wrap(err, "failed to read file data")
-		}
+	switch {
+	case err == io.ErrUnexpectedEOF || err == io.EOF:
+		return cnt, io.EOF
+	case err != nil:
+		return cnt, errors.Wrap(err, "failed to read file data")
 	}
 
-	r.offset += int64(cnt)
 	return cnt, nil
 }
 
+// Size returns the size of the object.
+func (r *Reader) Size() int64 {
+	return r.fileSize
+}
+
 func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	if whence < io.SeekStart || whence > io.SeekEnd {
 		return 0, errors.WithFields(
@@ -135,9 +186,25 @@ func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 		r.offset = r.fileSize + offset
 	}
 
+	if !r.readAhead.Contains(r.offset) {
+		r.readAhead.Invalidate()
+	}
+
 	return r.offset, nil
 }
 
+// SetReadAhead sets the size of the window buffered after each sequential read.
+// Reads smaller than the window are served from it without issuing a request.
+// A size of 0 disables the read-ahead.
+func (r *Reader) SetReadAhead(size int) {
+	r.readAhead = source.NewReadAhead(size)
+}
+
+// Identity returns the URI of the object and its generation.
+func (r *Reader) Identity() (id, version string) {
+	return "gs://" + r.BucketName + "/" + r.FilePath, strconv.FormatInt(r.generation, 10)
+}
+
 func (r *Reader) open(ctx context.Context) (err error) {
 	r.Bucket = r.Client.Bucket(r.BucketName)
 	r.Object = r.Bucket.Object(r.FilePath)
@@ -148,6 +215,10 @@ func (r *Reader) open(ctx context.Context) (err error) {
 	}
 
 	r.fileSize = objAttrs.Size
+	r.generation = objAttrs.Generation
+
+	// The reads are pinned to the generation of the object returned by Identity.
+	r.Object = r.Object.Generation(r.generation)
 
 	return err
 }
@@ -0,0 +1,23 @@
+package gcs
+
+import (
+	"net/http"
+
+	"github.com/hexbee-net/errors"
+	"google.golang.org/api/googleapi"
+)
+
+// IsRetryable returns true if err is a transient GCS error: a 429 or 5xx response.
+func IsRetryable(err error) bool {
+	if e, ok := errors.Cause(err).(*googleapi.Error); ok {
+		return e.Code == http.StatusTooManyRequests || e.Code >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// IsRetryable returns true if err is a transient GCS error.
+// It makes the Reader a retry.Classifier.
+func (r *Reader) IsRetryable(err error) bool {
+	return IsRetryable(err)
+}
@@ -7,9 +7,27 @@ import (
 	"github.com/hexbee-net/errors"
 )
 
+const errAborted = errors.Error("upload aborted")
+
+// WriterOptions contains the configuration of the upload of a Writer.
+type WriterOptions struct {
+	// ChunkSize is the size of the chunks of the resumable upload, rounded up
+	// to a multiple of 256KiB. Defaults to storage.Writer's default.
+	ChunkSize int
+	// KMSKeyName is the name of the Cloud KMS key used to encrypt the object.
+	KMSKeyName string
+	// ContentType is the MIME type of the object.
+	ContentType string
+	// Metadata is stored with the object.
+	Metadata map[string]string
+}
+
 type Writer struct {
 	file
 	fileWriter *storage.Writer
+	cancel     context.CancelFunc
+	options    WriterOptions
+	aborted    bool
 }
 
 // NewWriter creates an GCS Writer.
@@ -37,6 +55,11 @@ func NewWriter(ctx context.Context, projectID, bucketName, name string) (*Writer
 
 // NewWriterWithClient is the same as NewWriter but allows passing your own GCS client.
 func NewWriterWithClient(ctx context.Context, client *storage.Client, projectID, bucketName, name string) (*Writer, error) {
+	return NewWriterWithOptions(ctx, client, projectID, bucketName, name, WriterOptions{})
+}
+
+// NewWriterWithOptions is the same as NewWriterWithClient but allows configuring the upload.
+func NewWriterWithOptions(ctx context.Context, client *storage.Client, projectID, bucketName, name string, options WriterOptions) (*Writer, error) {
 	writer := &Writer{
 		file: file{
 			ProjectID:      projectID,
@@ -46,6 +69,7 @@ func NewWriterWithClient(ctx context.Context, client *storage.Client, projectID,
 			externalClient: true,
 			Client:         client,
 		},
+		options: options,
 	}
 
 	writer.create()
@@ -54,12 +78,23 @@ func NewWriterWithClient(ctx context.Context, client *storage.Client, projectID,
 }
 
 func (w *Writer) Write(p []byte) (n int, err error) {
+	if w.aborted {
+		return 0, errors.WithStack(errAborted)
+	}
+
 	return w.fileWriter.Write(p)
 }
 
 func (w *Writer) Close() error {
+	if w.aborted {
+		return errors.WithStack(errAborted)
+	}
+
 	if w.fileWriter != nil {
-		if err := w.fileWriter.Close(); err != nil {
+		err := w.fileWriter.Close()
+		w.cancel()
+
+		if err != nil {
 			return errors.Wrap(err, "failed to close GCS writer")
 		}
 	}
@@ -67,9 +102,40 @@ func (w *Writer) Close() error {
 	return w.file.Close()
 }
 
+// Abort cancels the resumable upload session, so that no object is created.
+// err is not used by GCS and is only accepted for consistency with the other writers.
+func (w *Writer) Abort(_ error) error {
+	if w.aborted {
+		return nil
+	}
+
+	w.aborted = true
+
+	// Cancelling the context of a storage.Writer aborts the upload.
+	w.cancel()
+
+	if w.fileWriter != nil {
+		_ = w.fileWriter.Close()
+	}
+
+	return w.file.Close()
+}
+
 func (w *Writer) create() {
+	var ctx context.Context
+
+	ctx, w.cancel = context.WithCancel(w.ctx)
+
 	w.Bucket = w.Client.Bucket(w.BucketName)
 	w.Object = w.Bucket.Object(w.FilePath)
 
-	w.fileWriter = w.Object.NewWriter(w.ctx)
+	w.fileWriter = w.Object.NewWriter(ctx)
+
+	if w.options.ChunkSize > 0 {
+		w.fileWriter.ChunkSize = w.options.ChunkSize
+	}
+
+	w.fileWriter.KMSKeyName = w.options.KMSKeyName
+	w.fileWriter.ContentType = w.options.ContentType
+	w.fileWriter.Metadata = w.options.Metadata
 }
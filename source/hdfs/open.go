@@ -0,0 +1,54 @@
+package hdfs
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/hexbee-net/parquet/source"
+)
+
+// UserOption is the key of the option holding the HDFS user,
+// when it is not part of the URI.
+const UserOption = "user"
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("hdfs", open, create)
+}
+
+// open opens an hdfs://[user@]host:port/path URI.
+// The client option accepts a *hdfs.Client.
+func open(_ context.Context, uri *url.URL, options *source.Options) (source.Reader, error) {
+	hosts, user := location(uri, options)
+
+	if c, ok := options.Client.(*hdfs.Client); ok {
+		return NewReaderWithClient(c, hosts, user, uri.Path)
+	}
+
+	return NewReader(hosts, user, uri.Path)
+}
+
+// create creates an hdfs://[user@]host:port/path URI, with the same options as open.
+func create(_ context.Context, uri *url.URL, options *source.Options) (source.Writer, error) {
+	hosts, user := location(uri, options)
+
+	if c, ok := options.Client.(*hdfs.Client); ok {
+		return NewWriterWithClient(c, hosts, user, uri.Path)
+	}
+
+	return NewWriter(hosts, user, uri.Path)
+}
+
+func location(uri *url.URL, options *source.Options) (hosts []string, user string) {
+	user = options.StringValue(UserOption)
+	if uri.User != nil {
+		user = uri.User.Username()
+	}
+
+	if uri.Host != "" {
+		hosts = []string{uri.Host}
+	}
+
+	return hosts, user
+}
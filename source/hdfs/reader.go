@@ -1,14 +1,30 @@
 package hdfs
 
 import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+
 	"github.com/colinmarc/hdfs/v2"
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const (
+	errWhence        = errors.Error("invalid whence")
+	errInvalidOffset = errors.Error("invalid offset")
 )
 
 type Reader struct {
 	file
 
-	reader *hdfs.FileReader
+	// mu serializes the accesses to reader, whose ReadAt moves its cursor.
+	mu        sync.Mutex
+	reader    *hdfs.FileReader
+	size      int64
+	offset    int64
+	readAhead *source.ReadAhead
 }
 
 func NewReader(hosts []string, user string, name string) (reader *Reader, err error) {
@@ -19,6 +35,7 @@ func NewReader(hosts []string, user string, name string) (reader *Reader, err er
 			FilePath:       name,
 			externalClient: false,
 		},
+		readAhead: source.NewReadAhead(source.DefaultReadAheadSize),
 	}
 
 	reader.client, err = hdfs.NewClient(hdfs.ClientOptions{
@@ -35,6 +52,8 @@ func NewReader(hosts []string, user string, name string) (reader *Reader, err er
 		return nil, errors.Wrap(err, "failed to create HDFS reader")
 	}
 
+	reader.size = reader.reader.Stat().Size()
+
 	return reader, nil
 }
 
@@ -47,6 +66,7 @@ func NewReaderWithClient(client *hdfs.Client, hosts []string, user string, name
 			client:         client,
 			externalClient: true,
 		},
+		readAhead: source.NewReadAhead(source.DefaultReadAheadSize),
 	}
 
 	reader.reader, err = reader.client.Open(name)
@@ -54,30 +74,82 @@ func NewReaderWithClient(client *hdfs.Client, hosts []string, user string, name
 		return nil, errors.Wrap(err, "failed to create HDFS reader")
 	}
 
+	reader.size = reader.reader.Stat().Size()
+
 	return reader, nil
 }
 
 func (r *Reader) Read(p []byte) (n int, err error) {
-	var cnt int
-	ln := len(p)
-
-	for n < ln {
-		cnt, err = r.reader.Read(p[n:])
+	n, err = r.readAhead.ReadAt(context.Background(), p, r.offset, r.readAt)
+	r.offset += int64(n)
 
-		n += cnt
-
-		if err != nil {
-			break
-		}
+	if err == io.EOF && n > 0 {
+		err = nil
 	}
+
 	return n, err
 }
 
 func (r *Reader) Seek(offset int64, whence int) (int64, error) {
-	return r.reader.Seek(offset, whence)
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.WithFields(
+			errors.WithStack(errWhence),
+			errors.Fields{
+				"whence": whence,
+			})
+	}
+
+	if offset < 0 || offset > r.size {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset":   offset,
+				"fileSize": r.size,
+			})
+	}
+
+	r.offset = offset
+
+	if !r.readAhead.Contains(r.offset) {
+		r.readAhead.Invalidate()
+	}
+
+	return r.offset, nil
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reader.ReadAt(p, off)
+}
+
+// SetReadAhead sets the size of the window buffered after each sequential read.
+// Reads smaller than the window are served from it without seeking the HDFS reader.
+// A size of 0 disables the read-ahead.
+func (r *Reader) SetReadAhead(size int) {
+	r.readAhead = source.NewReadAhead(size)
+}
+
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// Identity returns the URI of the file and its modification time.
+func (r *Reader) Identity() (id, version string) {
+	return "hdfs://" + r.FilePath, strconv.FormatInt(r.reader.Stat().ModTime().UnixNano(), 10)
 }
 
 func (r *Reader) Close() (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if r.reader != nil {
 		err = r.reader.Close()
 		r.reader = nil
@@ -89,3 +161,7 @@ func (r *Reader) Close() (err error) {
 
 	return r.file.Close()
 }
+
+func (r *Reader) readAt(_ context.Context, p []byte, off int64) (int, error) {
+	return r.ReadAt(p, off)
+}
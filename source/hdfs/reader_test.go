@@ -0,0 +1,51 @@
+package hdfs
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Run("Seek", TestReader_Seek)
+}
+
+func TestReader_Seek(t *testing.T) {
+	t.Parallel()
+
+	r := &Reader{size: 100}
+
+	for _, tt := range []struct {
+		offset   int64
+		whence   int
+		expected int64
+	}{
+		{offset: 10, whence: io.SeekStart, expected: 10},
+		{offset: 5, whence: io.SeekCurrent, expected: 15},
+		{offset: -8, whence: io.SeekEnd, expected: 92},
+		{offset: -100, whence: io.SeekEnd, expected: 0},
+		{offset: 100, whence: io.SeekStart, expected: 100},
+	} {
+		pos, err := r.Seek(tt.offset, tt.whence)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, pos)
+		assert.Equal(t, tt.expected, r.offset)
+	}
+
+	for _, tt := range []struct {
+		offset int64
+		whence int
+	}{
+		{offset: -1, whence: io.SeekStart},
+		{offset: 101, whence: io.SeekStart},
+		{offset: 1, whence: io.SeekCurrent},
+		{offset: 1, whence: io.SeekEnd},
+		{offset: -101, whence: io.SeekEnd},
+		{offset: 0, whence: 3},
+	} {
+		_, err := r.Seek(tt.offset, tt.whence)
+		assert.Error(t, err, "offset %d whence %d", tt.offset, tt.whence)
+	}
+}
@@ -33,6 +33,14 @@ func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	return r.file.Seek(offset, whence)
 }
 
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.file.ReadAt(p, off)
+}
+
+func (r *Reader) Size() int64 {
+	return r.fileHeader.Size
+}
+
 func (r *Reader) Close() error {
 	return r.file.Close()
 }
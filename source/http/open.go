@@ -0,0 +1,40 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const errCredentials = errors.Error("unsupported credentials type")
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("http", open, nil)
+	source.Register("https", open, nil)
+}
+
+// open opens an http(s) URI with a URLReader.
+// The client option accepts a *http.Client and the credentials option
+// a http.Header sent with each request, e.g. holding an Authorization header.
+func open(ctx context.Context, uri *url.URL, options *source.Options) (source.Reader, error) {
+	opts := URLReaderOptions{}
+
+	if c, ok := options.Client.(*http.Client); ok {
+		opts.Client = c
+	}
+
+	if options.Credentials != nil {
+		header, ok := options.Credentials.(http.Header)
+		if !ok {
+			return nil, errors.WithStack(errCredentials)
+		}
+
+		opts.Header = header
+	}
+
+	return NewURLReader(ctx, uri.String(), opts)
+}
@@ -0,0 +1,411 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hexbee-net/errors"
+)
+
+const (
+	// ErrObjectModified is returned when the remote object changed between two requests.
+	ErrObjectModified = errors.Error("remote object was modified")
+
+	errWhence        = errors.Error("invalid whence")
+	errInvalidOffset = errors.Error("invalid offset")
+	errUnknownSize   = errors.Error("failed to determine remote object size")
+
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 100 * time.Millisecond
+
+	rangeHeader       = "bytes=%d-%d"
+	rangeHeaderSuffix = "bytes=-1"
+)
+
+// StatusError is returned when the server answers with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return "unexpected HTTP status: " + e.Status
+}
+
+// URLReaderOptions contains the configuration of a URLReader.
+type URLReaderOptions struct {
+	// Client is the HTTP client used for the requests. http.DefaultClient is used if nil.
+	Client *http.Client
+	// Header contains additional headers sent with each request, e.g. for authorization.
+	Header http.Header
+	// MaxRetries is the number of times a request is retried on 5xx responses or
+	// transport errors. Defaults to 3, a negative value disables the retries.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled on each attempt. Defaults to 100ms.
+	RetryBackoff time.Duration
+}
+
+// URLReader reads a remote object served over HTTP(S) with range requests.
+// The object is expected not to change while it is read: the ETag and
+// Last-Modified headers returned by the server are validated on every request.
+type URLReader struct {
+	ctx     context.Context
+	url     string
+	options URLReaderOptions
+
+	size         int64
+	etag         string
+	lastModified string
+	offset       int64
+}
+
+// NewURLReader creates a Reader for the object served at url.
+// The size of the object is retrieved with a HEAD request, or with a suffix
+// range request if the server doesn't answer HEAD requests with a length.
+func NewURLReader(ctx context.Context, url string, options URLReaderOptions) (*URLReader, error) {
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+
+	if options.MaxRetries == 0 {
+		options.MaxRetries = defaultMaxRetries
+	}
+
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = defaultRetryBackoff
+	}
+
+	r := &URLReader{
+		ctx:     ctx,
+		url:     url,
+		options: options,
+		size:    -1,
+	}
+
+	if err := r.stat(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *URLReader) Read(p []byte) (n int, err error) {
+	return r.ReadContext(r.ctx, p)
+}
+
+// ReadContext is the same as Read but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *URLReader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	n, err = r.ReadAtContext(ctx, p, r.offset)
+	r.offset += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (r *URLReader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.ReadAtContext(r.ctx, p, off)
+}
+
+// ReadAtContext is the same as ReadAt but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *URLReader) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset": off,
+			})
+	}
+
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	want := p
+	if rem := r.size - off; int64(len(want)) > rem {
+		want = want[:rem]
+	}
+
+	err = r.do(ctx, func() error {
+		n, err = r.readRange(ctx, want, off)
+		return err
+	})
+	if err != nil {
+		return n, err
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (r *URLReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.WithFields(
+			errors.WithStack(errWhence),
+			errors.Fields{
+				"whence": whence,
+			})
+	}
+
+	if offset < 0 || offset > r.size {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset":   offset,
+				"fileSize": r.size,
+			})
+	}
+
+	r.offset = offset
+
+	return r.offset, nil
+}
+
+// Size returns the size of the remote object.
+func (r *URLReader) Size() int64 {
+	return r.size
+}
+
+// Identity returns the URL of the object and its ETag, or its modification date
+// if the server doesn't provide an ETag.
+func (r *URLReader) Identity() (id, version string) {
+	if r.etag != "" {
+		return r.url, r.etag
+	}
+
+	return r.url, r.lastModified
+}
+
+func (r *URLReader) Close() error {
+	return nil
+}
+
+// stat retrieves the size and the validators of the remote object.
+func (r *URLReader) stat(ctx context.Context) error {
+	err := r.do(ctx, func() error {
+		resp, err := r.request(ctx, http.MethodHead, nil)
+		if err != nil {
+			return err
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+		}
+
+		r.etag = resp.Header.Get("ETag")
+		r.lastModified = resp.Header.Get("Last-Modified")
+		r.size = resp.ContentLength
+
+		return nil
+	})
+	if err == nil && r.size >= 0 {
+		return nil
+	}
+
+	// Some servers don't support HEAD requests or don't return a length,
+	// a suffix range request gives the total size in the Content-Range header.
+	err = r.do(ctx, func() error {
+		header := http.Header{}
+		header.Set("Range", rangeHeaderSuffix)
+
+		resp, err := r.request(ctx, http.MethodGet, header)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+			r.size = parseContentRangeSize(resp.Header.Get("Content-Range"))
+		case http.StatusOK:
+			r.size = resp.ContentLength
+		default:
+			return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+		}
+
+		r.etag = resp.Header.Get("ETag")
+		r.lastModified = resp.Header.Get("Last-Modified")
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch remote object description")
+	}
+
+	if r.size < 0 {
+		return errors.WithStack(errUnknownSize)
+	}
+
+	return nil
+}
+
+// readRange issues a single range request and validates the response.
+func (r *URLReader) readRange(ctx context.Context, p []byte, off int64) (int, error) {
+	header := http.Header{}
+	header.Set("Range", fmt.Sprintf(rangeHeader, off, off+int64(len(p))-1))
+
+	switch {
+	case r.etag != "" && !strings.HasPrefix(r.etag, "W/"):
+		header.Set("If-Match", r.etag)
+	case r.lastModified != "":
+		header.Set("If-Unmodified-Since", r.lastModified)
+	}
+
+	resp, err := r.request(ctx, http.MethodGet, header)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body := io.Reader(resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server ignored the range, skip to the requested offset.
+		if _, err := io.CopyN(ioutil.Discard, body, off); err != nil {
+			return 0, errors.Wrap(err, "failed to skip response data")
+		}
+	case http.StatusPreconditionFailed:
+		return 0, errors.WithStack(ErrObjectModified)
+	default:
+		return 0, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	if err := r.validate(resp); err != nil {
+		return 0, err
+	}
+
+	n, err := io.ReadFull(body, p)
+	if err != nil {
+		return n, errors.Wrap(err, "failed to read response data")
+	}
+
+	return n, nil
+}
+
+// validate makes sure the response is for the same version of the object as the first one.
+func (r *URLReader) validate(resp *http.Response) error {
+	if etag := resp.Header.Get("ETag"); r.etag != "" && etag != "" && etag != r.etag {
+		return errors.WithFields(
+			errors.WithStack(ErrObjectModified),
+			errors.Fields{
+				"expected-etag": r.etag,
+				"actual-etag":   etag,
+			})
+	}
+
+	if lm := resp.Header.Get("Last-Modified"); r.lastModified != "" && lm != "" && lm != r.lastModified {
+		return errors.WithFields(
+			errors.WithStack(ErrObjectModified),
+			errors.Fields{
+				"expected-last-modified": r.lastModified,
+				"actual-last-modified":   lm,
+			})
+	}
+
+	return nil
+}
+
+func (r *URLReader) request(ctx context.Context, method string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+
+	for k, v := range r.options.Header {
+		req.Header[k] = v
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	return r.options.Client.Do(req)
+}
+
+// do runs fn, retrying it with an exponential backoff on 5xx responses and transport errors.
+func (r *URLReader) do(ctx context.Context, fn func() error) error {
+	backoff := r.options.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.options.MaxRetries || !IsRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		t := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+// IsRetryable returns true if err is a transient error worth retrying:
+// a 5xx or 429 status, or a transport error.
+func IsRetryable(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *StatusError:
+		return cause.StatusCode >= http.StatusInternalServerError || cause.StatusCode == http.StatusTooManyRequests
+	case nil:
+		return false
+	default:
+		return cause != ErrObjectModified && cause != context.Canceled && cause != context.DeadlineExceeded
+	}
+}
+
+// parseContentRangeSize returns the total size from a "bytes start-end/size" header, or -1.
+func parseContentRangeSize(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return -1
+	}
+
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return size
+}
+
+// IsRetryable returns true if err is a transient error.
+// It makes the URLReader a retry.Classifier.
+func (r *URLReader) IsRetryable(err error) bool {
+	return IsRetryable(err)
+}
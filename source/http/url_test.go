@@ -0,0 +1,154 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hexbee-net/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLReader(t *testing.T) {
+	t.Run("ReadAt", TestURLReader_ReadAt)
+	t.Run("ReadSeek", TestURLReader_ReadSeek)
+	t.Run("NoHead", TestURLReader_NoHead)
+	t.Run("RetryOn5xx", TestURLReader_RetryOn5xx)
+	t.Run("ObjectModified", TestURLReader_ObjectModified)
+}
+
+func testContent() []byte {
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	return data
+}
+
+func serveContent(etag *atomic.Value) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag.Load().(string))
+		http.ServeContent(w, r, "data.parquet", time.Unix(0, 0), bytes.NewReader(testContent()))
+	}
+}
+
+func newETag(v string) *atomic.Value {
+	etag := &atomic.Value{}
+	etag.Store(v)
+
+	return etag
+}
+
+func TestURLReader_ReadAt(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(serveContent(newETag(`"v1"`)))
+	defer srv.Close()
+
+	r, err := NewURLReader(context.Background(), srv.URL, URLReaderOptions{Client: srv.Client()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(256), r.Size())
+
+	p := make([]byte, 16)
+	n, err := r.ReadAt(p, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 16, n)
+	assert.Equal(t, testContent()[100:116], p)
+
+	n, err = r.ReadAt(p, 250)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, testContent()[250:], p[:n])
+}
+
+func TestURLReader_ReadSeek(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(serveContent(newETag(`"v1"`)))
+	defer srv.Close()
+
+	r, err := NewURLReader(context.Background(), srv.URL, URLReaderOptions{Client: srv.Client()})
+	require.NoError(t, err)
+
+	_, err = r.Seek(-8, io.SeekEnd)
+	require.NoError(t, err)
+
+	p := make([]byte, 8)
+	_, err = io.ReadFull(r, p)
+	require.NoError(t, err)
+	assert.Equal(t, testContent()[248:], p)
+
+	_, err = r.Read(p)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestURLReader_NoHead(t *testing.T) {
+	t.Parallel()
+
+	etag := newETag(`"v1"`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		serveContent(etag)(w, r)
+	}))
+	defer srv.Close()
+
+	r, err := NewURLReader(context.Background(), srv.URL, URLReaderOptions{Client: srv.Client()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(256), r.Size())
+}
+
+func TestURLReader_RetryOn5xx(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+
+	etag := newETag(`"v1"`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		serveContent(etag)(w, r)
+	}))
+	defer srv.Close()
+
+	r, err := NewURLReader(context.Background(), srv.URL, URLReaderOptions{Client: srv.Client(), RetryBackoff: time.Millisecond})
+	require.NoError(t, err)
+
+	p := make([]byte, 4)
+	_, err = r.ReadAt(p, 0)
+	require.NoError(t, err)
+	assert.Equal(t, testContent()[:4], p)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestURLReader_ObjectModified(t *testing.T) {
+	t.Parallel()
+
+	etag := newETag(`"v1"`)
+
+	srv := httptest.NewServer(serveContent(etag))
+	defer srv.Close()
+
+	r, err := NewURLReader(context.Background(), srv.URL, URLReaderOptions{Client: srv.Client()})
+	require.NoError(t, err)
+
+	etag.Store(`"v2"`)
+
+	_, err = r.ReadAt(make([]byte, 4), 0)
+	assert.Equal(t, ErrObjectModified, errors.Cause(err))
+}
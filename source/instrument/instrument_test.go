@@ -0,0 +1,93 @@
+package instrument
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hexbee-net/parquet/source"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Run("Stats", TestReader_Stats)
+	t.Run("Writer", TestReader_Writer)
+	t.Run("Histogram", TestReader_Histogram)
+}
+
+func TestReader_Stats(t *testing.T) {
+	t.Parallel()
+
+	stats := NewStats()
+
+	r, err := NewReader(memory.NewReader(make([]byte, 100)), "data.parquet", stats)
+	require.NoError(t, err)
+
+	p := make([]byte, 10)
+
+	_, err = r.Seek(20, io.SeekStart)
+	require.NoError(t, err)
+
+	_, err = r.ReadContext(source.WithColumnPath(context.Background(), []string{"a", "b"}), p)
+	require.NoError(t, err)
+
+	_, err = r.ReadAtContext(source.WithColumnPath(context.Background(), []string{"c"}), p, 95)
+	assert.Equal(t, io.EOF, err)
+
+	total := stats.Total()
+	assert.Equal(t, int64(15), total.BytesRead)
+	assert.Equal(t, int64(2), total.Reads)
+	assert.Equal(t, int64(1), total.Seeks)
+	assert.Equal(t, int64(0), total.Errors)
+	assert.Equal(t, uint64(2), total.ReadLatency.Count())
+
+	assert.Equal(t, total.BytesRead, stats.Files()["data.parquet"].BytesRead)
+
+	columns := stats.Columns()
+	assert.Equal(t, int64(10), columns["a.b"].BytesRead)
+	assert.Equal(t, int64(5), columns["c"].BytesRead)
+
+	// The column "a.b" isn't the column "b" of the group "a".
+	_, err = r.ReadAtContext(source.WithColumnPath(context.Background(), []string{"a.b"}), p, 0)
+	require.NoError(t, err)
+
+	columns = stats.Columns()
+	assert.Equal(t, int64(10), columns["a.b"].BytesRead)
+	assert.Equal(t, int64(10), columns[`a\.b`].BytesRead)
+}
+
+func TestReader_Writer(t *testing.T) {
+	t.Parallel()
+
+	var events []Event
+
+	w := NewWriter(memory.NewWriter(nil), "out.parquet", ObserverFunc(func(e Event) {
+		events = append(events, e)
+	}))
+
+	_, err := w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Len(t, events, 2)
+	assert.Equal(t, OpWrite, events[0].Op)
+	assert.Equal(t, 4, events[0].Bytes)
+	assert.Equal(t, OpClose, events[1].Op)
+	assert.Equal(t, int64(4), events[1].Offset)
+}
+
+func TestReader_Histogram(t *testing.T) {
+	t.Parallel()
+
+	h := newHistogram([]time.Duration{time.Millisecond, time.Second})
+	h.observe(time.Microsecond)
+	h.observe(time.Millisecond)
+	h.observe(2 * time.Millisecond)
+	h.observe(time.Minute)
+
+	assert.Equal(t, []uint64{2, 1, 1}, h.Counts)
+	assert.Equal(t, uint64(4), h.Count())
+}
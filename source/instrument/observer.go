@@ -0,0 +1,76 @@
+// Package instrument provides source wrappers reporting the I/O operations
+// they issue (bytes, requests, seeks, latency) to an Observer.
+package instrument
+
+import (
+	"time"
+)
+
+// Op is the kind of an I/O operation.
+type Op int
+
+const (
+	OpRead Op = iota + 1
+	OpReadAt
+	OpWrite
+	OpSeek
+	OpClose
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpRead:
+		return "read"
+	case OpReadAt:
+		return "read-at"
+	case OpWrite:
+		return "write"
+	case OpSeek:
+		return "seek"
+	case OpClose:
+		return "close"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes an I/O operation issued to the wrapped source.
+type Event struct {
+	// File is the name given to the wrapper.
+	File string
+	// Column is the path of the column the operation was issued for, if known.
+	Column []string
+	// Op is the kind of operation.
+	Op Op
+	// Offset is the offset of the operation in the file.
+	Offset int64
+	// Bytes is the number of bytes read or written.
+	Bytes int
+	// Duration is the time spent in the wrapped source.
+	Duration time.Duration
+	// Err is the error returned by the wrapped source, io.EOF included.
+	Err error
+}
+
+// Observer receives the events of the instrumented sources.
+// Observe is called synchronously and may be called concurrently.
+type Observer interface {
+	Observe(e Event)
+}
+
+// ObserverFunc is a function implementing Observer.
+type ObserverFunc func(e Event)
+
+// Observe calls f(e).
+func (f ObserverFunc) Observe(e Event) {
+	f(e)
+}
+
+// Observers returns an Observer forwarding the events to all the given observers.
+func Observers(observers ...Observer) Observer {
+	return ObserverFunc(func(e Event) {
+		for _, o := range observers {
+			o.Observe(e)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package instrument
+
+import (
+	"context"
+	"time"
+
+	"github.com/hexbee-net/parquet/source"
+)
+
+// Reader reports the operations issued to a source.Reader to an Observer.
+// The column of the reads is taken from the context set with source.WithColumnPath.
+type Reader struct {
+	inner    source.Reader
+	at       source.ReaderAt
+	src      source.Reader
+	file     string
+	observer Observer
+	offset   int64
+}
+
+// NewReader wraps r, reporting its operations to observer under the given file name.
+// If file is empty, the identity of r is used when it implements source.Identifier.
+// Sources that don't implement source.ReaderAt are wrapped in a source.SeekReaderAt.
+func NewReader(r source.Reader, file string, observer Observer) (*Reader, error) {
+	if file == "" {
+		if i, ok := r.(source.Identifier); ok {
+			file, _ = i.Identity()
+		}
+	}
+
+	at, err := source.NewReaderAt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	inner, ok := at.(source.Reader)
+	if !ok {
+		inner = r
+	}
+
+	return &Reader{
+		inner:    inner,
+		at:       at,
+		src:      r,
+		file:     file,
+		observer: observer,
+	}, nil
+}
+
+func (r *Reader) Read(p []byte) (n int, err error) {
+	return r.ReadContext(context.Background(), p)
+}
+
+// ReadContext is the same as Read but forwards ctx to the wrapped source
+// and attributes the read to the column carried by ctx.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = source.ReadContext(ctx, r.inner, p)
+
+	r.observe(ctx, OpRead, r.offset, n, start, err)
+	r.offset += int64(n)
+
+	return n, err
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.ReadAtContext(context.Background(), p, off)
+}
+
+// ReadAtContext is the same as ReadAt but forwards ctx to the wrapped source
+// and attributes the read to the column carried by ctx.
+func (r *Reader) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
+	start := time.Now()
+	n, err = source.ReadAtContext(ctx, r.at, p, off)
+
+	r.observe(ctx, OpReadAt, off, n, start, err)
+
+	return n, err
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	start := time.Now()
+	pos, err := r.inner.Seek(offset, whence)
+
+	if err == nil {
+		r.offset = pos
+	}
+
+	r.observe(context.Background(), OpSeek, pos, 0, start, err)
+
+	return pos, err
+}
+
+// Size returns the size of the wrapped source.
+func (r *Reader) Size() int64 {
+	return r.at.Size()
+}
+
+// Identity returns the identity of the wrapped source, or empty strings if it has none.
+func (r *Reader) Identity() (id, version string) {
+	if i, ok := r.src.(source.Identifier); ok {
+		return i.Identity()
+	}
+
+	return "", ""
+}
+
+// HintRange forwards the hint to the wrapped source.
+func (r *Reader) HintRange(kind source.RangeKind, offset, length int64) {
+	if h, ok := r.src.(source.RangeHinter); ok {
+		h.HintRange(kind, offset, length)
+	}
+}
+
+func (r *Reader) Close() error {
+	start := time.Now()
+	err := r.inner.Close()
+
+	r.observe(context.Background(), OpClose, r.offset, 0, start, err)
+
+	return err
+}
+
+func (r *Reader) observe(ctx context.Context, op Op, offset int64, n int, start time.Time, err error) {
+	r.observer.Observe(Event{
+		File:     r.file,
+		Column:   source.ColumnPath(ctx),
+		Op:       op,
+		Offset:   offset,
+		Bytes:    n,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// Writer reports the operations issued to a source.Writer to an Observer.
+type Writer struct {
+	inner    source.Writer
+	file     string
+	observer Observer
+	offset   int64
+}
+
+// NewWriter wraps w, reporting its operations to observer under the given file name.
+func NewWriter(w source.Writer, file string, observer Observer) *Writer {
+	return &Writer{
+		inner:    w,
+		file:     file,
+		observer: observer,
+	}
+}
+
+func (w *Writer) Write(p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = w.inner.Write(p)
+
+	w.observe(OpWrite, n, start, err)
+	w.offset += int64(n)
+
+	return n, err
+}
+
+func (w *Writer) Close() error {
+	start := time.Now()
+	err := w.inner.Close()
+
+	w.observe(OpClose, 0, start, err)
+
+	return err
+}
+
+// Abort forwards the abort to the wrapped writer if it implements source.Aborter.
+func (w *Writer) Abort(err error) error {
+	if a, ok := w.inner.(source.Aborter); ok {
+		return a.Abort(err)
+	}
+
+	return nil
+}
+
+func (w *Writer) observe(op Op, n int, start time.Time, err error) {
+	w.observer.Observe(Event{
+		File:     w.file,
+		Op:       op,
+		Offset:   w.offset,
+		Bytes:    n,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
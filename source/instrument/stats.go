@@ -0,0 +1,193 @@
+package instrument
+
+import (
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hexbee-net/parquet/internal/columnpath"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram buckets used by NewStats.
+//
+//nolint:gochecknoglobals // Read-only default configuration.
+var DefaultLatencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Histogram counts durations in buckets. Counts[i] is the number of durations
+// lower than or equal to Bounds[i] and greater than Bounds[i-1]; the last count
+// holds the durations greater than all the bounds.
+type Histogram struct {
+	Bounds []time.Duration
+	Counts []uint64
+	Sum    time.Duration
+}
+
+func newHistogram(bounds []time.Duration) Histogram {
+	return Histogram{
+		Bounds: bounds,
+		Counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+func (h *Histogram) observe(d time.Duration) {
+	i := sort.Search(len(h.Bounds), func(i int) bool { return d <= h.Bounds[i] })
+	h.Counts[i]++
+	h.Sum += d
+}
+
+// Count returns the number of durations in the histogram.
+func (h Histogram) Count() uint64 {
+	var n uint64
+	for _, c := range h.Counts {
+		n += c
+	}
+
+	return n
+}
+
+func (h Histogram) clone() Histogram {
+	c := h
+	c.Counts = append([]uint64(nil), h.Counts...)
+
+	return c
+}
+
+// Counters are the totals of the operations of a file or a column.
+type Counters struct {
+	BytesRead    int64
+	BytesWritten int64
+	Reads        int64
+	Writes       int64
+	Seeks        int64
+	Errors       int64
+	ReadLatency  Histogram
+	WriteLatency Histogram
+}
+
+func (c *Counters) observe(e Event) {
+	if e.Err != nil && e.Err != io.EOF {
+		c.Errors++
+	}
+
+	switch e.Op {
+	case OpRead, OpReadAt:
+		c.Reads++
+		c.BytesRead += int64(e.Bytes)
+		c.ReadLatency.observe(e.Duration)
+	case OpWrite:
+		c.Writes++
+		c.BytesWritten += int64(e.Bytes)
+		c.WriteLatency.observe(e.Duration)
+	case OpSeek:
+		c.Seeks++
+	case OpClose:
+	}
+}
+
+func (c Counters) clone() Counters {
+	c.ReadLatency = c.ReadLatency.clone()
+	c.WriteLatency = c.WriteLatency.clone()
+
+	return c
+}
+
+// Stats is an Observer aggregating the events per file and per column.
+type Stats struct {
+	buckets []time.Duration
+
+	mu      sync.Mutex
+	total   Counters
+	files   map[string]*Counters
+	columns map[string]*Counters
+}
+
+// NewStats creates a Stats observer using DefaultLatencyBuckets.
+func NewStats() *Stats {
+	return NewStatsWithBuckets(DefaultLatencyBuckets)
+}
+
+// NewStatsWithBuckets creates a Stats observer with the given latency histogram bounds, in increasing order.
+func NewStatsWithBuckets(buckets []time.Duration) *Stats {
+	return &Stats{
+		buckets: buckets,
+		total:   newCounters(buckets),
+		files:   make(map[string]*Counters),
+		columns: make(map[string]*Counters),
+	}
+}
+
+func newCounters(buckets []time.Duration) Counters {
+	return Counters{
+		ReadLatency:  newHistogram(buckets),
+		WriteLatency: newHistogram(buckets),
+	}
+}
+
+// Observe aggregates e.
+func (s *Stats) Observe(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total.observe(e)
+	s.counters(s.files, e.File).observe(e)
+
+	if len(e.Column) > 0 {
+		s.counters(s.columns, columnpath.Format(e.Column)).observe(e)
+	}
+}
+
+func (s *Stats) counters(m map[string]*Counters, key string) *Counters {
+	c, ok := m[key]
+	if !ok {
+		cs := newCounters(s.buckets)
+		c = &cs
+		m[key] = c
+	}
+
+	return c
+}
+
+// Total returns the counters of all the operations.
+func (s *Stats) Total() Counters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.total.clone()
+}
+
+// Files returns the counters of the operations per file name.
+func (s *Stats) Files() map[string]Counters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return cloneCounters(s.files)
+}
+
+// Columns returns the counters of the reads per column path, in the dotted notation
+// of schema.FormatPath.
+func (s *Stats) Columns() map[string]Counters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return cloneCounters(s.columns)
+}
+
+func cloneCounters(m map[string]*Counters) map[string]Counters {
+	res := make(map[string]Counters, len(m))
+	for k, c := range m {
+		res[k] = c.clone()
+	}
+
+	return res
+}
@@ -0,0 +1,130 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hexbee-net/errors"
+)
+
+const (
+	errWriterClosed = errors.Error("writer is already closed")
+
+	filePermission = 0o644
+)
+
+// AtomicWriter writes a local file atomically: the data is written to a
+// temporary file in the target directory, which is synced and renamed to the
+// target path on Close. A crash before Close never leaves a partial file at the
+// target path.
+type AtomicWriter struct {
+	FilePath string
+	file     *os.File
+	done     bool
+}
+
+// NewAtomicWriter creates a local file Writer that only creates the file at path when it is closed.
+func NewAtomicWriter(path string) (w *AtomicWriter, err error) {
+	w = &AtomicWriter{
+		FilePath: path,
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	if w.file, err = ioutil.TempFile(dir, "."+name+".tmp-*"); err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary file")
+	}
+
+	return w, nil
+}
+
+func (w *AtomicWriter) Write(p []byte) (n int, err error) {
+	if w.done {
+		return 0, errors.WithStack(errWriterClosed)
+	}
+
+	return w.file.Write(p)
+}
+
+// Close syncs the written data to disk and moves the file to its target path.
+// The temporary file is removed if any of these steps fails.
+func (w *AtomicWriter) Close() error {
+	if w.done {
+		return errors.WithStack(errWriterClosed)
+	}
+
+	w.done = true
+
+	if err := w.commit(); err != nil {
+		_ = os.Remove(w.file.Name())
+		return err
+	}
+
+	return nil
+}
+
+// Abort discards the written data and removes the temporary file.
+// It has no effect if the writer is already closed.
+func (w *AtomicWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+
+	w.done = true
+
+	_ = w.file.Close()
+
+	if err := os.Remove(w.file.Name()); err != nil {
+		return errors.Wrap(err, "failed to remove temporary file")
+	}
+
+	return nil
+}
+
+func (w *AtomicWriter) commit() error {
+	if err := w.file.Chmod(filePermission); err != nil {
+		_ = w.file.Close()
+		return errors.Wrap(err, "failed to set file permissions")
+	}
+
+	if err := w.file.Sync(); err != nil {
+		_ = w.file.Close()
+		return errors.Wrap(err, "failed to sync file")
+	}
+
+	if err := w.file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close file")
+	}
+
+	if err := os.Rename(w.file.Name(), w.FilePath); err != nil {
+		return errors.Wrap(err, "failed to rename file")
+	}
+
+	return syncDir(filepath.Dir(w.FilePath))
+}
+
+// syncDir makes the rename of a file in dir durable.
+func syncDir(dir string) error {
+	// Directories can't be synced on Windows, where renames are durable once they return.
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to open directory")
+	}
+
+	defer func() { _ = d.Close() }()
+
+	if err := d.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync directory")
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicWriter(t *testing.T) {
+	t.Run("Close", TestAtomicWriter_Close)
+	t.Run("Abort", TestAtomicWriter_Abort)
+}
+
+func TestAtomicWriter_Close(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "parquet-local")
+	require.NoError(t, err)
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "data.parquet")
+
+	w, err := NewAtomicWriter(path)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, w.Close())
+	assert.Error(t, w.Close())
+	assert.NoError(t, w.Abort())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("PAR1"), data)
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, infos, 1)
+}
+
+func TestAtomicWriter_Abort(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "parquet-local")
+	require.NoError(t, err)
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "data.parquet")
+
+	w, err := NewAtomicWriter(path)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+	require.NoError(t, w.Abort())
+
+	_, err = w.Write([]byte("PAR1"))
+	assert.Error(t, err)
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+}
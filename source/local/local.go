@@ -2,6 +2,9 @@ package local
 
 import (
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/hexbee-net/errors"
 )
@@ -9,6 +12,8 @@ import (
 type File struct {
 	FilePath string
 	file     *os.File
+	size     int64
+	modTime  time.Time
 }
 
 // NewReader creates a local file Reader.
@@ -22,6 +27,16 @@ func NewReader(path string) (r *File, err error) {
 		return nil, errors.Wrap(err, "failed to open source file")
 	}
 
+	info, err := r.file.Stat()
+	if err != nil {
+		_ = r.file.Close()
+
+		return nil, errors.Wrap(err, "failed to get source file info")
+	}
+
+	r.size = info.Size()
+	r.modTime = info.ModTime()
+
 	return r, nil
 }
 
@@ -62,6 +77,26 @@ func (f *File) Seek(offset int64, whence int) (int64, error) {
 	return f.file.Seek(offset, whence)
 }
 
+func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	return f.file.ReadAt(p, off)
+}
+
+// Size returns the size of the file when it was opened for reading.
+func (f *File) Size() int64 {
+	return f.size
+}
+
+// Identity returns the path of the file and its modification time and size
+// when it was opened for reading.
+func (f *File) Identity() (id, version string) {
+	name, err := filepath.Abs(f.file.Name())
+	if err != nil {
+		name = f.file.Name()
+	}
+
+	return "file://" + name, strconv.FormatInt(f.modTime.UnixNano(), 10) + "-" + strconv.FormatInt(f.size, 10)
+}
+
 // Writer //////////////////////////////
 
 func (f *File) Write(p []byte) (n int, err error) {
@@ -0,0 +1,53 @@
+package local
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hexbee-net/parquet/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	t.Run("ReadAt", TestFile_ReadAt)
+}
+
+func TestFile_ReadAt(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "parquet-local")
+	require.NoError(t, err)
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "data.parquet")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0123456789"), 0o600))
+
+	r, err := NewReader(path)
+	require.NoError(t, err)
+
+	defer func() { _ = r.Close() }()
+
+	var ra source.ReaderAt = r
+
+	assert.Equal(t, int64(10), ra.Size())
+
+	p := make([]byte, 4)
+
+	n, err := ra.ReadAt(p, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "2345", string(p[:n]))
+
+	n, err = ra.ReadAt(p, 8)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "89", string(p[:n]))
+
+	// The positioned reads don't move the cursor.
+	n, err = r.Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, "0123", string(p[:n]))
+}
@@ -0,0 +1,41 @@
+package local
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hexbee-net/parquet/source"
+)
+
+// AtomicOption is the key of the option enabling atomic writes, see NewAtomicWriter.
+const AtomicOption = "atomic"
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("file", open, create)
+}
+
+func open(_ context.Context, uri *url.URL, _ *source.Options) (source.Reader, error) {
+	return NewReader(filePath(uri))
+}
+
+func create(_ context.Context, uri *url.URL, options *source.Options) (source.Writer, error) {
+	if atomic, _ := options.Value(AtomicOption).(bool); atomic {
+		return NewAtomicWriter(filePath(uri))
+	}
+
+	return NewWriter(filePath(uri))
+}
+
+// filePath returns the path of a file URI: file:///abs/path, file:rel/path or a plain path.
+func filePath(uri *url.URL) string {
+	if uri.Opaque != "" {
+		return uri.Opaque
+	}
+
+	if uri.Host != "" && uri.Host != "localhost" {
+		return uri.Host + uri.Path
+	}
+
+	return uri.Path
+}
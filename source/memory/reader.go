@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"bytes"
+)
+
+type Reader struct {
+	*bytes.Reader
+}
+
+// NewReader creates an in-memory Reader serving the content of buf.
+func NewReader(buf []byte) *Reader {
+	return &Reader{
+		Reader: bytes.NewReader(buf),
+	}
+}
+
+func (r *Reader) Close() error {
+	return nil
+}
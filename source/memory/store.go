@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const errNotFound = errors.Error("object not found")
+
+// Store is a set of named in-memory objects.
+// It backs the mem:// URI scheme, mostly useful for tests.
+type Store struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+// NewStore creates an empty Store.
+func NewStore() *Store {
+	return &Store{
+		objects: make(map[string][]byte),
+	}
+}
+
+//nolint:gochecknoglobals // The store backing the mem:// scheme.
+var defaultStore = NewStore()
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("mem", open, create)
+}
+
+// DefaultStore returns the Store used by the mem:// scheme.
+func DefaultStore() *Store {
+	return defaultStore
+}
+
+// Open returns a Reader on the content of the named object.
+func (s *Store) Open(name string) (*Reader, error) {
+	s.mu.RLock()
+	data, ok := s.objects[name]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.WithFields(
+			errors.WithStack(errNotFound),
+			errors.Fields{
+				"name": name,
+			})
+	}
+
+	return NewReader(data), nil
+}
+
+// Create returns a Writer whose content is stored under name when it is closed.
+func (s *Store) Create(name string) *StoreWriter {
+	return &StoreWriter{store: s, name: name}
+}
+
+// Put stores data under name, replacing any previous object.
+func (s *Store) Put(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[name] = data
+}
+
+// Get returns the content of the named object.
+func (s *Store) Get(name string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.objects[name]
+
+	return data, ok
+}
+
+// Delete removes the named object.
+func (s *Store) Delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, name)
+}
+
+// StoreWriter buffers the data written to a Store object until it is closed.
+type StoreWriter struct {
+	bytes.Buffer
+
+	store *Store
+	name  string
+}
+
+// Close stores the written data in the Store.
+func (w *StoreWriter) Close() error {
+	w.store.Put(w.name, w.Bytes())
+	return nil
+}
+
+func open(_ context.Context, uri *url.URL, _ *source.Options) (source.Reader, error) {
+	return defaultStore.Open(objectName(uri))
+}
+
+func create(_ context.Context, uri *url.URL, _ *source.Options) (source.Writer, error) {
+	return defaultStore.Create(objectName(uri)), nil
+}
+
+// objectName returns the name of the object designated by a mem://name URI.
+func objectName(uri *url.URL) string {
+	if uri.Opaque != "" {
+		return uri.Opaque
+	}
+
+	return uri.Host + uri.Path
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/hexbee-net/parquet/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("RoundTrip", TestStore_RoundTrip)
+	t.Run("NotFound", TestStore_NotFound)
+	t.Run("UnsupportedScheme", TestStore_UnsupportedScheme)
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	w, err := source.Create(context.Background(), "mem://bucket/round-trip.parquet")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r, err := source.Open(context.Background(), "mem://bucket/round-trip.parquet")
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("PAR1"), data)
+	assert.NoError(t, r.Close())
+
+	DefaultStore().Delete("bucket/round-trip.parquet")
+
+	_, ok := DefaultStore().Get("bucket/round-trip.parquet")
+	assert.False(t, ok)
+}
+
+func TestStore_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := source.Open(context.Background(), "mem://missing")
+	assert.Error(t, err)
+}
+
+func TestStore_UnsupportedScheme(t *testing.T) {
+	t.Parallel()
+
+	_, err := source.Open(context.Background(), "ftp://host/file")
+	assert.Error(t, err)
+	assert.Contains(t, source.Schemes(), "mem")
+}
@@ -17,3 +17,8 @@ func NewWriter(buf []byte) *Writer {
 func (w Writer) Close() error {
 	return nil
 }
+
+// Reader returns a Reader serving the data written so far.
+func (w *Writer) Reader() *Reader {
+	return NewReader(w.Bytes())
+}
@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Reader(t *testing.T) {
+	t.Parallel()
+
+	w := NewWriter(nil)
+
+	_, err := w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err := ioutil.ReadAll(w.Reader())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("PAR1"), data)
+}
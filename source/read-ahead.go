@@ -0,0 +1,108 @@
+package source
+
+import (
+	"context"
+	"io"
+)
+
+// DefaultReadAheadSize is the size of the read-ahead window used by the remote readers.
+const DefaultReadAheadSize = 1 << 20 // 1 MiB
+
+// FetchFunc reads len(p) bytes at offset off from the underlying storage.
+// It follows the io.ReaderAt semantics.
+type FetchFunc func(ctx context.Context, p []byte, off int64) (n int, err error)
+
+// ReadAhead is a window of data buffered after the last sequential read,
+// used to serve small reads (thrift headers, levels...) without issuing a
+// request to the storage for each of them.
+// The window is keyed by absolute offset, it is not safe for concurrent use.
+type ReadAhead struct {
+	size   int
+	buf    []byte
+	offset int64
+	eof    bool
+}
+
+// NewReadAhead creates a read-ahead window of the given size.
+// It returns nil if size is not positive, which disables the read-ahead.
+func NewReadAhead(size int) *ReadAhead {
+	if size <= 0 {
+		return nil
+	}
+
+	return &ReadAhead{size: size}
+}
+
+// Contains returns true if the data at offset off is buffered in the window.
+func (b *ReadAhead) Contains(off int64) bool {
+	return b != nil && off >= b.offset && off < b.offset+int64(len(b.buf))
+}
+
+// Invalidate drops the buffered data.
+func (b *ReadAhead) Invalidate() {
+	if b == nil {
+		return
+	}
+
+	b.buf = b.buf[:0]
+	b.offset = 0
+	b.eof = false
+}
+
+// ReadAt reads len(p) bytes at offset off, serving them from the window when
+// possible and refilling it with fetch otherwise. Reads larger than the window
+// bypass it.
+func (b *ReadAhead) ReadAt(ctx context.Context, p []byte, off int64, fetch FetchFunc) (n int, err error) {
+	if b == nil {
+		return fetch(ctx, p, off)
+	}
+
+	for n < len(p) {
+		pos := off + int64(n)
+
+		if b.Contains(pos) {
+			n += copy(p[n:], b.buf[pos-b.offset:])
+			continue
+		}
+
+		if b.eof && pos >= b.offset+int64(len(b.buf)) && len(b.buf) > 0 {
+			return n, io.EOF
+		}
+
+		if len(p)-n >= b.size {
+			c, err := fetch(ctx, p[n:], pos)
+			return n + c, err
+		}
+
+		if err := b.fill(ctx, pos, fetch); err != nil {
+			return n, err
+		}
+
+		if len(b.buf) == 0 {
+			return n, io.EOF
+		}
+	}
+
+	return n, nil
+}
+
+func (b *ReadAhead) fill(ctx context.Context, off int64, fetch FetchFunc) error {
+	if cap(b.buf) < b.size {
+		b.buf = make([]byte, b.size)
+	}
+
+	b.buf = b.buf[:b.size]
+
+	n, err := fetch(ctx, b.buf, off)
+
+	b.buf = b.buf[:n]
+	b.offset = off
+	b.eof = err == io.EOF
+
+	if err != nil && err != io.EOF {
+		b.Invalidate()
+		return err
+	}
+
+	return nil
+}
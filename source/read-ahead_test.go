@@ -0,0 +1,123 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAhead(t *testing.T) {
+	t.Run("SmallReads", TestReadAhead_SmallReads)
+	t.Run("LargeRead", TestReadAhead_LargeRead)
+	t.Run("EOF", TestReadAhead_EOF)
+	t.Run("Invalidate", TestReadAhead_Invalidate)
+	t.Run("Disabled", TestReadAhead_Disabled)
+}
+
+type countingFetcher struct {
+	r     *bytes.Reader
+	calls int
+}
+
+func (f *countingFetcher) fetch(_ context.Context, p []byte, off int64) (int, error) {
+	f.calls++
+	return f.r.ReadAt(p, off)
+}
+
+func newCountingFetcher() *countingFetcher {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	return &countingFetcher{r: bytes.NewReader(data)}
+}
+
+func TestReadAhead_SmallReads(t *testing.T) {
+	t.Parallel()
+
+	f := newCountingFetcher()
+	b := NewReadAhead(32)
+
+	p := make([]byte, 4)
+
+	for off := int64(0); off < 40; off += 4 {
+		n, err := b.ReadAt(context.Background(), p, off, f.fetch)
+		require.NoError(t, err)
+		assert.Equal(t, 4, n)
+		assert.Equal(t, []byte{byte(off), byte(off + 1), byte(off + 2), byte(off + 3)}, p)
+	}
+
+	assert.Equal(t, 2, f.calls)
+}
+
+func TestReadAhead_LargeRead(t *testing.T) {
+	t.Parallel()
+
+	f := newCountingFetcher()
+	b := NewReadAhead(16)
+
+	p := make([]byte, 40)
+	n, err := b.ReadAt(context.Background(), p, 10, f.fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 40, n)
+	assert.Equal(t, byte(10), p[0])
+	assert.Equal(t, byte(49), p[39])
+	assert.Equal(t, 1, f.calls)
+}
+
+func TestReadAhead_EOF(t *testing.T) {
+	t.Parallel()
+
+	f := newCountingFetcher()
+	b := NewReadAhead(16)
+
+	p := make([]byte, 8)
+	n, err := b.ReadAt(context.Background(), p, 96, f.fetch)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []byte{96, 97, 98, 99}, p[:n])
+
+	_, err = b.ReadAt(context.Background(), p, 100, f.fetch)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReadAhead_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	f := newCountingFetcher()
+	b := NewReadAhead(16)
+
+	p := make([]byte, 4)
+	_, err := b.ReadAt(context.Background(), p, 0, f.fetch)
+	require.NoError(t, err)
+	assert.True(t, b.Contains(15))
+	assert.False(t, b.Contains(16))
+
+	b.Invalidate()
+	assert.False(t, b.Contains(0))
+
+	_, err = b.ReadAt(context.Background(), p, 0, f.fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.calls)
+}
+
+func TestReadAhead_Disabled(t *testing.T) {
+	t.Parallel()
+
+	f := newCountingFetcher()
+	b := NewReadAhead(0)
+	assert.Nil(t, b)
+	assert.False(t, b.Contains(0))
+
+	p := make([]byte, 4)
+	_, err := b.ReadAt(context.Background(), p, 0, f.fetch)
+	require.NoError(t, err)
+	_, err = b.ReadAt(context.Background(), p, 4, f.fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.calls)
+}
@@ -0,0 +1,108 @@
+package source
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hexbee-net/errors"
+)
+
+// SeekReaderAt adapts a Seek-based Reader to the ReaderAt interface.
+// Positioned reads are serialised through the underlying cursor, which is
+// restored after each of them, so the adapter can still be used as a Reader.
+type SeekReaderAt struct {
+	mu    sync.Mutex
+	inner Reader
+	size  int64
+}
+
+// NewReaderAt returns a ReaderAt for r. If r already implements ReaderAt it is
+// returned as is, otherwise it is wrapped in a SeekReaderAt.
+func NewReaderAt(r Reader) (ReaderAt, error) {
+	if ra, ok := r.(ReaderAt); ok {
+		return ra, nil
+	}
+
+	return NewSeekReaderAt(r)
+}
+
+// NewSeekReaderAt creates a SeekReaderAt from a legacy Seek-based Reader.
+func NewSeekReaderAt(r Reader) (*SeekReaderAt, error) {
+	pos, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current reader position")
+	}
+
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get reader size")
+	}
+
+	if _, err := r.Seek(pos, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "failed to restore reader position")
+	}
+
+	return &SeekReaderAt{
+		inner: r,
+		size:  size,
+	}, nil
+}
+
+func (r *SeekReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.WithFields(
+			errors.New("negative offset"),
+			errors.Fields{
+				"offset": off,
+			})
+	}
+
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pos, err := r.inner.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get current reader position")
+	}
+
+	if _, err := r.inner.Seek(off, io.SeekStart); err != nil {
+		return 0, errors.Wrap(err, "failed to seek to read offset")
+	}
+
+	n, err = io.ReadFull(r.inner, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	if _, seekErr := r.inner.Seek(pos, io.SeekStart); seekErr != nil && err == nil {
+		err = errors.Wrap(seekErr, "failed to restore reader position")
+	}
+
+	return n, err
+}
+
+func (r *SeekReaderAt) Size() int64 {
+	return r.size
+}
+
+func (r *SeekReaderAt) Read(p []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.inner.Read(p)
+}
+
+func (r *SeekReaderAt) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.inner.Seek(offset, whence)
+}
+
+func (r *SeekReaderAt) Close() error {
+	return r.inner.Close()
+}
@@ -0,0 +1,136 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderAt(t *testing.T) {
+	t.Run("SeekReaderAt", TestReaderAt_SeekReaderAt)
+	t.Run("EOF", TestReaderAt_EOF)
+	t.Run("NegativeOffset", TestReaderAt_NegativeOffset)
+	t.Run("Native", TestReaderAt_Native)
+	t.Run("Context", TestReaderAt_Context)
+}
+
+// bytesReader is an in-memory source supporting positioned reads.
+type bytesReader struct {
+	*bytes.Reader
+}
+
+func (r *bytesReader) Close() error {
+	return nil
+}
+
+// seekReader hides the ReadAt method of an in-memory source.
+type seekReader struct {
+	Reader
+}
+
+func newSeekReader(data string) *seekReader {
+	return &seekReader{Reader: &bytesReader{Reader: bytes.NewReader([]byte(data))}}
+}
+
+func TestReaderAt_SeekReaderAt(t *testing.T) {
+	t.Parallel()
+
+	src := newSeekReader("0123456789")
+
+	_, err := src.Seek(2, io.SeekStart)
+	require.NoError(t, err)
+
+	ra, err := NewReaderAt(src)
+	require.NoError(t, err)
+	require.IsType(t, &SeekReaderAt{}, ra)
+	assert.Equal(t, int64(10), ra.Size())
+
+	p := make([]byte, 3)
+
+	n, err := ra.ReadAt(p, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "567", string(p))
+
+	// The cursor of the source is restored.
+	n, err = ra.(*SeekReaderAt).Read(p)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "234", string(p))
+}
+
+func TestReaderAt_EOF(t *testing.T) {
+	t.Parallel()
+
+	ra, err := NewSeekReaderAt(newSeekReader("0123456789"))
+	require.NoError(t, err)
+
+	p := make([]byte, 4)
+
+	n, err := ra.ReadAt(p, 8)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "89", string(p[:n]))
+
+	n, err = ra.ReadAt(p, 10)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestReaderAt_NegativeOffset(t *testing.T) {
+	t.Parallel()
+
+	ra, err := NewSeekReaderAt(newSeekReader("0123456789"))
+	require.NoError(t, err)
+
+	_, err = ra.ReadAt(make([]byte, 1), -1)
+	assert.Error(t, err)
+}
+
+func TestReaderAt_Native(t *testing.T) {
+	t.Parallel()
+
+	src := &bytesReader{Reader: bytes.NewReader([]byte("0123456789"))}
+
+	ra, err := NewReaderAt(src)
+	require.NoError(t, err)
+	assert.Equal(t, src, ra)
+}
+
+// contextReaderAt records the context of its positioned reads.
+type contextReaderAt struct {
+	io.ReaderAt
+
+	ctx context.Context
+}
+
+func (r *contextReaderAt) ReadAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	r.ctx = ctx
+	return r.ReadAt(p, off)
+}
+
+func TestReaderAt_Context(t *testing.T) {
+	t.Parallel()
+
+	src := &bytesReader{Reader: bytes.NewReader([]byte("0123456789"))}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ReadAtContext(ctx, src, make([]byte, 1), 0)
+	assert.True(t, stderrors.Is(err, context.Canceled))
+
+	cr := &contextReaderAt{ReaderAt: src}
+	p := make([]byte, 2)
+
+	n, err := ReadAtContext(ctx, cr, p, 3)
+	require.NoError(t, err, "the context is handled by the reader")
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "34", string(p))
+	assert.Equal(t, ctx, cr.ctx)
+}
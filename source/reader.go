@@ -1,9 +1,92 @@
 package source
 
-import "io"
+import (
+	"context"
+	"io"
+
+	"github.com/hexbee-net/errors"
+)
 
 type Reader interface {
 	io.Reader
 	io.Seeker
 	io.Closer
 }
+
+// ContextReader is implemented by readers that can honour a per-call context
+// instead of the one captured at construction.
+type ContextReader interface {
+	ReadContext(ctx context.Context, p []byte) (n int, err error)
+}
+
+// ReadContext reads from r using the provided context.
+// If r does not implement ContextReader, the context is only checked
+// for cancellation before the read is issued.
+func ReadContext(ctx context.Context, r io.Reader, p []byte) (int, error) {
+	if cr, ok := r.(ContextReader); ok {
+		return cr.ReadContext(ctx, p)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.Read(p)
+}
+
+// ReaderAt is implemented by sources that can serve reads at arbitrary offsets
+// without going through a shared cursor. Such sources can be read concurrently.
+type ReaderAt interface {
+	io.ReaderAt
+
+	// Size returns the total size of the source in bytes.
+	Size() int64
+}
+
+// ContextReaderAt is implemented by readers that can honour a per-call context
+// for positioned reads.
+type ContextReaderAt interface {
+	ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error)
+}
+
+// ReadAtContext reads from r at the given offset using the provided context.
+// If r does not implement ContextReaderAt, the context is only checked
+// for cancellation before the read is issued.
+func ReadAtContext(ctx context.Context, r io.ReaderAt, p []byte, off int64) (int, error) {
+	if cr, ok := r.(ContextReaderAt); ok {
+		return cr.ReadAtContext(ctx, p, off)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.ReadAt(p, off)
+}
+
+// Identifier is implemented by sources that can identify the object they read
+// and its version (ETag, generation, modification time...).
+type Identifier interface {
+	Identity() (id string, version string)
+}
+
+// ErrObjectChanged is returned by the readers of remote objects when the object was
+// overwritten or deleted since it was opened: the reads are pinned to the version
+// returned by Identity, so that one file is never read from two versions.
+const ErrObjectChanged = errors.Error("object changed since it was opened")
+
+// RangeKind describes what a byte range of a parquet file holds.
+type RangeKind int
+
+const (
+	// RangeFooter is the range holding the file metadata, its length and the trailing magic.
+	RangeFooter RangeKind = iota + 1
+	// RangeDictionaryPage is the range holding the dictionary page of a column chunk.
+	RangeDictionaryPage
+)
+
+// RangeHinter is implemented by sources that can make use of hints about
+// what a byte range holds before it is read.
+type RangeHinter interface {
+	HintRange(kind RangeKind, offset, length int64)
+}
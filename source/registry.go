@@ -0,0 +1,233 @@
+package source
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hexbee-net/errors"
+)
+
+const (
+	errUnsupportedScheme = errors.Error("unsupported URI scheme")
+	errCreateUnsupported = errors.Error("scheme does not support creating objects")
+	errOpenUnsupported   = errors.Error("scheme does not support opening objects")
+	errInvalidURI        = errors.Error("invalid URI")
+
+	defaultScheme = "file"
+)
+
+// OpenFunc opens a Reader on the object designated by uri.
+type OpenFunc func(ctx context.Context, uri *url.URL, options *Options) (Reader, error)
+
+// CreateFunc creates a Writer on the object designated by uri.
+type CreateFunc func(ctx context.Context, uri *url.URL, options *Options) (Writer, error)
+
+// Options contains the settings passed to the backends by Open and Create.
+// Each backend documents the types it accepts for Credentials and Client.
+type Options struct {
+	// Credentials used to access the storage.
+	Credentials interface{}
+	// Region of the storage, for backends that need one.
+	Region string
+	// Client is a preconfigured client for the storage.
+	Client interface{}
+	// Values contains backend specific settings.
+	Values map[string]interface{}
+}
+
+// Option configures the Options passed to the backends.
+type Option func(*Options)
+
+// WithCredentials sets the credentials used to access the storage.
+func WithCredentials(credentials interface{}) Option {
+	return func(o *Options) {
+		o.Credentials = credentials
+	}
+}
+
+// WithRegion sets the region of the storage.
+func WithRegion(region string) Option {
+	return func(o *Options) {
+		o.Region = region
+	}
+}
+
+// WithClient sets the client used to access the storage.
+func WithClient(client interface{}) Option {
+	return func(o *Options) {
+		o.Client = client
+	}
+}
+
+// WithOption sets a backend specific setting.
+func WithOption(key string, value interface{}) Option {
+	return func(o *Options) {
+		if o.Values == nil {
+			o.Values = make(map[string]interface{})
+		}
+
+		o.Values[key] = value
+	}
+}
+
+// Value returns the backend specific setting stored under key, or nil.
+func (o *Options) Value(key string) interface{} {
+	if o == nil || o.Values == nil {
+		return nil
+	}
+
+	return o.Values[key]
+}
+
+// StringValue returns the backend specific setting stored under key
+// if it is a string, or an empty string.
+func (o *Options) StringValue(key string) string {
+	s, _ := o.Value(key).(string)
+	return s
+}
+
+type backend struct {
+	open   OpenFunc
+	create CreateFunc
+}
+
+//nolint:gochecknoglobals // The registry is filled by the backend packages when they are imported.
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]backend)
+)
+
+// Register makes a backend available to Open and Create for the given URI scheme.
+// Backend packages register themselves when they are imported, so a program only
+// needs to import the packages of the backends it uses:
+//
+//	import _ "github.com/hexbee-net/parquet/source/s3"
+//
+// Either open or create may be nil if the backend doesn't support it.
+// Registering the same scheme twice panics.
+func Register(scheme string, open OpenFunc, create CreateFunc) {
+	scheme = strings.ToLower(scheme)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[scheme]; ok {
+		panic("source: Register called twice for scheme " + scheme)
+	}
+
+	registry[scheme] = backend{open: open, create: create}
+}
+
+// Schemes returns the sorted list of the registered URI schemes.
+func Schemes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	schemes := make([]string, 0, len(registry))
+	for scheme := range registry {
+		schemes = append(schemes, scheme)
+	}
+
+	sort.Strings(schemes)
+
+	return schemes
+}
+
+// Open opens a Reader on the object designated by uri, using the backend
+// registered for its scheme. URIs without a scheme are local file paths.
+func Open(ctx context.Context, uri string, opts ...Option) (Reader, error) {
+	u, b, err := lookup(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.open == nil {
+		return nil, errors.WithFields(
+			errors.WithStack(errOpenUnsupported),
+			errors.Fields{
+				"scheme": u.Scheme,
+			})
+	}
+
+	r, err := b.open(ctx, u, newOptions(opts))
+	if err != nil {
+		return nil, errors.WithFields(
+			errors.Wrap(err, "failed to open source"),
+			errors.Fields{
+				"uri": uri,
+			})
+	}
+
+	return r, nil
+}
+
+// Create creates a Writer on the object designated by uri, using the backend
+// registered for its scheme. URIs without a scheme are local file paths.
+func Create(ctx context.Context, uri string, opts ...Option) (Writer, error) {
+	u, b, err := lookup(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.create == nil {
+		return nil, errors.WithFields(
+			errors.WithStack(errCreateUnsupported),
+			errors.Fields{
+				"scheme": u.Scheme,
+			})
+	}
+
+	w, err := b.create(ctx, u, newOptions(opts))
+	if err != nil {
+		return nil, errors.WithFields(
+			errors.Wrap(err, "failed to create target"),
+			errors.Fields{
+				"uri": uri,
+			})
+	}
+
+	return w, nil
+}
+
+func newOptions(opts []Option) *Options {
+	options := &Options{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+func lookup(uri string) (*url.URL, backend, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, backend{}, errors.WithFields(
+			errors.Wrap(err, errInvalidURI.Error()),
+			errors.Fields{
+				"uri": uri,
+			})
+	}
+
+	// Single letter schemes are Windows drive letters.
+	if len(u.Scheme) <= 1 {
+		u = &url.URL{Scheme: defaultScheme, Path: uri}
+	}
+
+	registryMu.RLock()
+	b, ok := registry[strings.ToLower(u.Scheme)]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, backend{}, errors.WithFields(
+			errors.WithStack(errUnsupportedScheme),
+			errors.Fields{
+				"scheme": u.Scheme,
+			})
+	}
+
+	return u, b, nil
+}
@@ -0,0 +1,144 @@
+package retry
+
+import (
+	"context"
+	"io"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+// Reader retries the reads of a source that fail with a transient error.
+// Failed reads are resumed at the offset following the bytes already received,
+// so that a retried read never returns duplicated or missing data.
+type Reader struct {
+	src     source.Reader
+	inner   source.Reader
+	at      source.ReaderAt
+	options Options
+	offset  int64
+}
+
+// NewReader wraps r with the retry policy described by options.
+// Sources that don't implement source.ReaderAt are wrapped in a source.SeekReaderAt.
+func NewReader(r source.Reader, options Options) (*Reader, error) {
+	options.setDefaults()
+
+	if options.Retryable == nil {
+		if c, ok := r.(Classifier); ok {
+			options.Retryable = Any(c.IsRetryable, IsTransient)
+		} else {
+			options.Retryable = IsTransient
+		}
+	}
+
+	at, err := source.NewReaderAt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	inner, ok := at.(source.Reader)
+	if !ok {
+		inner = r
+	}
+
+	return &Reader{
+		src:     r,
+		inner:   inner,
+		at:      at,
+		options: options,
+	}, nil
+}
+
+func (r *Reader) Read(p []byte) (n int, err error) {
+	return r.ReadContext(context.Background(), p)
+}
+
+// ReadContext is the same as Read but uses the provided context for the reads
+// of the wrapped source and to interrupt the waits between attempts.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	err = r.options.Do(ctx, func(attempt int) error {
+		if attempt > 1 {
+			// The cursor of the source is unknown after a failed read.
+			if _, err := r.inner.Seek(r.offset+int64(n), io.SeekStart); err != nil {
+				return errors.Wrap(err, "failed to resume read")
+			}
+		}
+
+		for n < len(p) {
+			c, err := source.ReadContext(ctx, r.inner, p[n:])
+			n += c
+
+			if err != nil {
+				return err
+			}
+
+			if c == 0 {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	r.offset += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.ReadAtContext(context.Background(), p, off)
+}
+
+// ReadAtContext is the same as ReadAt but uses the provided context for the reads
+// of the wrapped source and to interrupt the waits between attempts.
+func (r *Reader) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
+	err = r.options.Do(ctx, func(int) error {
+		c, err := source.ReadAtContext(ctx, r.at, p[n:], off+int64(n))
+		n += c
+
+		return err
+	})
+
+	return n, err
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.inner.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+
+	r.offset = pos
+
+	return pos, nil
+}
+
+// Size returns the size of the wrapped source.
+func (r *Reader) Size() int64 {
+	return r.at.Size()
+}
+
+// Identity returns the identity of the wrapped source, or empty strings if it has none.
+func (r *Reader) Identity() (id, version string) {
+	if i, ok := r.src.(source.Identifier); ok {
+		return i.Identity()
+	}
+
+	return "", ""
+}
+
+// HintRange forwards the hint to the wrapped source.
+func (r *Reader) HintRange(kind source.RangeKind, offset, length int64) {
+	if h, ok := r.src.(source.RangeHinter); ok {
+		h.HintRange(kind, offset, length)
+	}
+}
+
+func (r *Reader) Close() error {
+	return r.inner.Close()
+}
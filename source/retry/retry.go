@@ -0,0 +1,181 @@
+// Package retry provides a source wrapper retrying the reads that fail with
+// transient errors (throttling, 5xx responses, connection resets...).
+package retry
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/hexbee-net/errors"
+)
+
+const (
+	DefaultMaxAttempts    = 5
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+	DefaultMultiplier     = 2
+	DefaultJitter         = 0.5
+)
+
+// Classifier is implemented by sources that know which of their errors are transient.
+// The Reader uses it when no RetryableFunc is configured.
+type Classifier interface {
+	IsRetryable(err error) bool
+}
+
+// RetryableFunc returns true if the read that failed with err should be retried.
+type RetryableFunc func(err error) bool
+
+// Options contains the retry policy of a Reader.
+type Options struct {
+	// MaxAttempts is the maximum number of attempts of a read, including the first one.
+	// Defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Defaults to DefaultInitialBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+	// Multiplier is the factor applied to the delay after each attempt. Defaults to DefaultMultiplier.
+	Multiplier float64
+	// Jitter is the fraction of the delay that is randomized, between 0 and 1.
+	// Defaults to DefaultJitter, a negative value disables the jitter.
+	Jitter float64
+	// Deadline is the maximum time spent retrying a single read, 0 means no limit.
+	Deadline time.Duration
+	// Retryable classifies the errors. Defaults to the Classifier implemented by the
+	// wrapped source, or IsTransient.
+	Retryable RetryableFunc
+	// OnRetry is called before waiting for each retry, e.g. to log it.
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+func (o *Options) setDefaults() {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultInitialBackoff
+	}
+
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if o.Multiplier < 1 {
+		o.Multiplier = DefaultMultiplier
+	}
+
+	switch {
+	case o.Jitter == 0:
+		o.Jitter = DefaultJitter
+	case o.Jitter < 0:
+		o.Jitter = 0
+	case o.Jitter > 1:
+		o.Jitter = 1
+	}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable,
+// or the attempts or the deadline of the policy are exhausted.
+func (o Options) Do(ctx context.Context, fn func(attempt int) error) error {
+	o.setDefaults()
+
+	retryable := o.Retryable
+	if retryable == nil {
+		retryable = IsTransient
+	}
+
+	var deadline time.Time
+	if o.Deadline > 0 {
+		deadline = time.Now().Add(o.Deadline)
+	}
+
+	backoff := o.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn(attempt)
+		if err == nil || attempt >= o.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		delay := o.jitter(backoff)
+
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			return err
+		}
+
+		if o.OnRetry != nil {
+			o.OnRetry(attempt, delay, err)
+		}
+
+		t := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+
+		backoff = time.Duration(float64(backoff) * o.Multiplier)
+		if backoff > o.MaxBackoff {
+			backoff = o.MaxBackoff
+		}
+	}
+}
+
+func (o Options) jitter(d time.Duration) time.Duration {
+	if o.Jitter == 0 {
+		return d
+	}
+
+	//nolint:gosec // The jitter doesn't need a cryptographic random source.
+	return d - time.Duration(o.Jitter*rand.Float64()*float64(d))
+}
+
+// IsTransient returns true for the errors that are usually transient whatever
+// the backend: timeouts, connection resets and truncated responses.
+// Context cancellations and io.EOF are never transient.
+func IsTransient(err error) bool {
+	cause := errors.Cause(err)
+
+	switch cause {
+	case nil, io.EOF, context.Canceled, context.DeadlineExceeded:
+		return false
+	case io.ErrUnexpectedEOF, syscall.ECONNRESET, syscall.ECONNABORTED, syscall.EPIPE:
+		return true
+	}
+
+	switch e := cause.(type) {
+	case *url.Error:
+		return e.Timeout() || IsTransient(e.Err)
+	case *net.OpError:
+		return e.Timeout() || IsTransient(e.Err)
+	case *os.SyscallError:
+		return IsTransient(e.Err)
+	case net.Error:
+		return e.Timeout()
+	}
+
+	return false
+}
+
+// Any returns a RetryableFunc accepting the errors accepted by any of fns.
+func Any(fns ...RetryableFunc) RetryableFunc {
+	return func(err error) bool {
+		for _, fn := range fns {
+			if fn(err) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,144 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Run("Read", TestReader_Read)
+	t.Run("ReadAt", TestReader_ReadAt)
+	t.Run("NotRetryable", TestReader_NotRetryable)
+	t.Run("MaxAttempts", TestReader_MaxAttempts)
+	t.Run("Deadline", TestReader_Deadline)
+}
+
+const errFatal = errors.Error("fatal")
+
+// flakyReader fails every other read after returning half of the requested data.
+type flakyReader struct {
+	*memory.Reader
+
+	err   error
+	calls int
+}
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	r.calls++
+	if r.calls%2 == 1 && len(p) > 1 {
+		n, _ := r.Reader.Read(p[:len(p)/2])
+		return n, r.err
+	}
+
+	return r.Reader.Read(p)
+}
+
+func (r *flakyReader) ReadAt(p []byte, off int64) (int, error) {
+	r.calls++
+	if r.calls%2 == 1 && len(p) > 1 {
+		n, _ := r.Reader.ReadAt(p[:len(p)/2], off)
+		return n, r.err
+	}
+
+	return r.Reader.ReadAt(p, off)
+}
+
+func testData() []byte {
+	data := make([]byte, 64)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	return data
+}
+
+func newFlakyReader(err error) *flakyReader {
+	return &flakyReader{Reader: memory.NewReader(testData()), err: err}
+}
+
+func fastOptions() Options {
+	return Options{InitialBackoff: time.Microsecond, Jitter: -1}
+}
+
+func TestReader_Read(t *testing.T) {
+	t.Parallel()
+
+	var retries int
+
+	opts := fastOptions()
+	opts.OnRetry = func(attempt int, delay time.Duration, err error) {
+		retries++
+	}
+
+	r, err := NewReader(newFlakyReader(io.ErrUnexpectedEOF), opts)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, testData(), data)
+	assert.NotZero(t, retries)
+}
+
+func TestReader_ReadAt(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewReader(newFlakyReader(io.ErrUnexpectedEOF), fastOptions())
+	require.NoError(t, err)
+
+	p := make([]byte, 16)
+	n, err := r.ReadAt(p, 32)
+	require.NoError(t, err)
+	assert.Equal(t, 16, n)
+	assert.Equal(t, testData()[32:48], p)
+	assert.Equal(t, int64(64), r.Size())
+}
+
+func TestReader_NotRetryable(t *testing.T) {
+	t.Parallel()
+
+	src := newFlakyReader(errFatal)
+
+	r, err := NewReader(src, fastOptions())
+	require.NoError(t, err)
+
+	_, err = r.ReadAt(make([]byte, 16), 0)
+	assert.Equal(t, errFatal, errors.Cause(err))
+	assert.Equal(t, 1, src.calls)
+}
+
+func TestReader_MaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	opts := fastOptions()
+	opts.MaxAttempts = 3
+
+	err := opts.Do(context.Background(), func(int) error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestReader_Deadline(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	opts := Options{InitialBackoff: time.Hour, Deadline: time.Second}
+
+	err := opts.Do(context.Background(), func(int) error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,77 @@
+package s3
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
+)
+
+const errCredentials = errors.Error("unsupported credentials type")
+
+//nolint:gochecknoinits // Backends register their URI scheme when imported.
+func init() {
+	source.Register("s3", open, create)
+}
+
+// open opens an s3://bucket/key URI.
+// The client option accepts an s3iface.S3API or a client.ConfigProvider
+// and the credentials option a *credentials.Credentials.
+func open(ctx context.Context, uri *url.URL, options *source.Options) (source.Reader, error) {
+	s3Client, err := newClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReaderWithClient(ctx, s3Client, uri.Host, strings.TrimPrefix(uri.Path, "/"))
+}
+
+// create creates an s3://bucket/key URI, with the same options as open.
+func create(ctx context.Context, uri *url.URL, options *source.Options) (source.Writer, error) {
+	s3Client, err := newClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWriterWithClient(ctx, s3Client, uri.Host, strings.TrimPrefix(uri.Path, "/"), nil)
+}
+
+func newClient(options *source.Options) (s3iface.S3API, error) {
+	if c, ok := options.Client.(s3iface.S3API); ok {
+		return c, nil
+	}
+
+	config := aws.NewConfig()
+
+	if options.Region != "" {
+		config = config.WithRegion(options.Region)
+	}
+
+	if options.Credentials != nil {
+		creds, ok := options.Credentials.(*credentials.Credentials)
+		if !ok {
+			return nil, errors.WithStack(errCredentials)
+		}
+
+		config = config.WithCredentials(creds)
+	}
+
+	if provider, ok := options.Client.(client.ConfigProvider); ok {
+		return s3.New(provider, config), nil
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+
+	return s3.New(sess), nil
+}
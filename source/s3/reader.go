@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/hexbee-net/errors"
+	"github.com/hexbee-net/parquet/source"
 )
 
 const (
@@ -22,9 +25,11 @@ type Reader struct {
 	file
 
 	fileSize   int64
+	etag       string
 	offset     int64
 	whence     int
 	downloader *s3manager.Downloader
+	readAhead  *source.ReadAhead
 }
 
 // NewReader creates an S3 Reader.
@@ -45,6 +50,7 @@ func NewReaderWithClient(ctx context.Context, s3Client s3iface.S3API, bucket, ke
 		},
 
 		downloader: s3Downloader,
+		readAhead:  source.NewReadAhead(source.DefaultReadAheadSize),
 	}
 
 	input := &s3.HeadObjectInput{
@@ -61,20 +67,38 @@ func NewReaderWithClient(ctx context.Context, s3Client s3iface.S3API, bucket, ke
 		reader.fileSize = *headObject.ContentLength
 	}
 
+	if headObject.ETag != nil {
+		reader.etag = *headObject.ETag
+	}
+
 	return &reader, nil
 }
 
 func (r *Reader) Read(p []byte) (n int, err error) {
+	return r.ReadContext(r.ctx, p)
+}
+
+// ReadContext is the same as Read but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *Reader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
 	if r.fileSize > 0 && r.offset >= r.fileSize {
 		return 0, io.EOF
 	}
 
+	if r.fileSize > 0 {
+		n, err = r.readAhead.ReadAt(ctx, p, r.offset, r.ReadAtContext)
+		r.offset += int64(n)
+
+		if err == io.EOF && n > 0 {
+			err = nil
+		}
+
+		return n, err
+	}
+
 	numBytes := len(p)
 	bytesRange := r.getBytesRange(r.offset, r.whence, numBytes)
-	getObj := &s3.GetObjectInput{
-		Bucket: aws.String(r.BucketName),
-		Key:    aws.String(r.Key),
-	}
+	getObj := r.getObjectInput()
 
 	if len(bytesRange) > 0 {
 		getObj.Range = aws.String(bytesRange)
@@ -82,9 +106,9 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 
 	wab := aws.NewWriteAtBuffer(p)
 
-	bytesDownloaded, err := r.downloader.DownloadWithContext(r.ctx, wab, getObj)
+	bytesDownloaded, err := r.downloader.DownloadWithContext(ctx, wab, getObj)
 	if err != nil {
-		return 0, err
+		return 0, r.downloadError(err)
 	}
 
 	r.offset += bytesDownloaded
@@ -98,6 +122,59 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 	return int(bytesDownloaded), err
 }
 
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	return r.ReadAtContext(r.ctx, p, off)
+}
+
+// ReadAtContext is the same as ReadAt but uses the provided context for the request
+// instead of the one the reader was created with.
+func (r *Reader) ReadAtContext(ctx context.Context, p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.WithFields(
+			errors.WithStack(errInvalidOffset),
+			errors.Fields{
+				"offset": off,
+			})
+	}
+
+	if off >= r.fileSize {
+		return 0, io.EOF
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	getObj := r.getObjectInput()
+	getObj.Range = aws.String(r.getBytesRange(off, io.SeekStart, len(p)))
+
+	wab := aws.NewWriteAtBuffer(p)
+
+	bytesDownloaded, err := r.downloader.DownloadWithContext(ctx, wab, getObj)
+	if err != nil {
+		return 0, r.downloadError(err)
+	}
+
+	n = int(bytesDownloaded)
+
+	if buf := wab.Bytes(); len(buf) > len(p) {
+		// backing buffer reassigned, copy over some of the data
+		copy(p, buf)
+		n = len(p)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Size returns the size of the object, or 0 if it is unknown.
+func (r *Reader) Size() int64 {
+	return r.fileSize
+}
+
 // Seek tracks the offset for the next Read. Has no effect on Write.
 func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	if whence < io.SeekStart || whence > io.SeekEnd {
@@ -141,19 +218,65 @@ func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 						"fileSize": r.fileSize,
 					})
 			}
+
+			offset += r.fileSize
+			whence = io.SeekStart
 		}
 	}
 
 	r.offset = offset
 	r.whence = whence
 
+	if !r.readAhead.Contains(r.offset) {
+		r.readAhead.Invalidate()
+	}
+
 	return r.offset, nil
 }
 
+// SetReadAhead sets the size of the window buffered after each sequential read.
+// Reads smaller than the window are served from it without issuing a request.
+// A size of 0 disables the read-ahead.
+func (r *Reader) SetReadAhead(size int) {
+	r.readAhead = source.NewReadAhead(size)
+}
+
+// Identity returns the URI of the object and its ETag.
+func (r *Reader) Identity() (id, version string) {
+	return "s3://" + r.BucketName + "/" + r.Key, r.etag
+}
+
 func (r *Reader) Close() error {
 	return nil
 }
 
+// getObjectInput returns the request of the object, pinned to the ETag returned by Identity.
+func (r *Reader) getObjectInput() *s3.GetObjectInput {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(r.BucketName),
+		Key:    aws.String(r.Key),
+	}
+
+	if r.etag != "" {
+		input.IfMatch = aws.String(r.etag)
+	}
+
+	return input
+}
+
+// downloadError returns source.ErrObjectChanged when the object doesn't match the ETag of the request anymore.
+func (r *Reader) downloadError(err error) error {
+	if e, ok := errors.Cause(err).(awserr.RequestFailure); ok && e.StatusCode() == http.StatusPreconditionFailed {
+		return errors.WithFields(
+			errors.WithStack(source.ErrObjectChanged),
+			errors.Fields{
+				"etag": r.etag,
+			})
+	}
+
+	return err
+}
+
 func (r *Reader) getBytesRange(offset int64, whence int, numBytes int) string {
 	var (
 		byteRange string
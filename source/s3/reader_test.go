@@ -0,0 +1,180 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/hexbee-net/parquet/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	t.Run("Seek", TestReader_Seek)
+	t.Run("ReadAt", TestReader_ReadAt)
+	t.Run("ObjectChanged", TestReader_ObjectChanged)
+}
+
+func TestReader_Seek(t *testing.T) {
+	t.Parallel()
+
+	r := &Reader{fileSize: 100}
+
+	for _, tt := range []struct {
+		offset   int64
+		whence   int
+		expected int64
+	}{
+		{offset: 10, whence: io.SeekStart, expected: 10},
+		{offset: 5, whence: io.SeekCurrent, expected: 15},
+		{offset: -8, whence: io.SeekEnd, expected: 92},
+		{offset: -100, whence: io.SeekEnd, expected: 0},
+		{offset: 100, whence: io.SeekStart, expected: 100},
+	} {
+		pos, err := r.Seek(tt.offset, tt.whence)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, pos)
+		assert.Equal(t, tt.expected, r.offset)
+		assert.NotEqual(t, io.SeekEnd, r.whence)
+	}
+
+	for _, tt := range []struct {
+		offset int64
+		whence int
+	}{
+		{offset: -1, whence: io.SeekStart},
+		{offset: 101, whence: io.SeekStart},
+		{offset: 1, whence: io.SeekCurrent},
+		{offset: 1, whence: io.SeekEnd},
+		{offset: -101, whence: io.SeekEnd},
+		{offset: 0, whence: 3},
+	} {
+		_, err := r.Seek(tt.offset, tt.whence)
+		assert.Error(t, err, "offset %d whence %d", tt.offset, tt.whence)
+	}
+}
+
+// objectS3 serves the ranges of an object.
+type objectS3 struct {
+	s3iface.S3API
+
+	data []byte
+	// etag is the ETag of the object, "etag" when empty.
+	etag string
+}
+
+func (f *objectS3) currentETag() string {
+	if f.etag == "" {
+		return "etag"
+	}
+
+	return f.etag
+}
+
+func (f *objectS3) HeadObjectWithContext(aws.Context, *s3.HeadObjectInput, ...request.Option) (*s3.HeadObjectOutput, error) {
+	return &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(f.data))),
+		ETag:          aws.String(f.currentETag()),
+	}, nil
+}
+
+func (f *objectS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if input.IfMatch != nil && *input.IfMatch != f.currentETag() {
+		return nil, awserr.NewRequestFailure(awserr.New("PreconditionFailed", "etag mismatch", nil), http.StatusPreconditionFailed, "")
+	}
+
+	var begin, end int64
+
+	if _, err := fmt.Sscanf(aws.StringValue(input.Range), "bytes=%d-%d", &begin, &end); err != nil {
+		return nil, err
+	}
+
+	if end >= int64(len(f.data)) {
+		end = int64(len(f.data)) - 1
+	}
+
+	return &s3.GetObjectOutput{
+		Body:          ioutil.NopCloser(bytes.NewReader(f.data[begin : end+1])),
+		ContentLength: aws.Int64(end - begin + 1),
+		ContentRange:  aws.String(fmt.Sprintf("bytes %d-%d/%d", begin, end, len(f.data))),
+	}, nil
+}
+
+func TestReader_ReadAt(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewReaderWithClient(context.Background(), &objectS3{data: []byte("0123456789")}, "bucket", "key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), r.Size())
+
+	p := make([]byte, 4)
+
+	n, err := r.ReadAt(p, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "3456", string(p))
+
+	n, err = r.ReadAt(p, 8)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "89", string(p[:n]))
+
+	_, err = r.ReadAt(p, 10)
+	assert.Equal(t, io.EOF, err)
+
+	_, err = r.ReadAt(p, -1)
+	assert.Error(t, err)
+
+	// The reads past the end of an empty object aren't sent.
+	empty, err := NewReaderWithClient(context.Background(), &objectS3{}, "bucket", "key")
+	require.NoError(t, err)
+
+	for _, off := range []int64{0, 5} {
+		_, err = empty.ReadAt(p, off)
+		assert.Equal(t, io.EOF, err, "offset %d", off)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The context of the call is used for the request.
+	_, err = r.ReadAtContext(ctx, p, 0)
+	assert.Error(t, err)
+}
+
+func TestReader_ObjectChanged(t *testing.T) {
+	t.Parallel()
+
+	obj := &objectS3{data: []byte("0123456789")}
+
+	r, err := NewReaderWithClient(context.Background(), obj, "bucket", "key")
+	require.NoError(t, err)
+
+	p := make([]byte, 4)
+
+	_, err = r.ReadAt(p, 0)
+	require.NoError(t, err)
+
+	// The object is overwritten: the reads don't mix its two versions.
+	obj.etag = "other"
+
+	_, err = r.ReadAt(p, 4)
+	assert.True(t, stderrors.Is(err, source.ErrObjectChanged))
+
+	_, version := r.Identity()
+	assert.Equal(t, "etag", version)
+}
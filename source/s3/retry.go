@@ -0,0 +1,23 @@
+package s3
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hexbee-net/errors"
+)
+
+// IsRetryable returns true if err is a transient S3 error: throttling
+// (SlowDown, 503, 429), 5xx responses or connection errors.
+func IsRetryable(err error) bool {
+	cause := errors.Cause(err)
+	if cause == nil {
+		return false
+	}
+
+	return request.IsErrorThrottle(cause) || request.IsErrorRetryable(cause)
+}
+
+// IsRetryable returns true if err is a transient S3 error.
+// It makes the Reader a retry.Classifier.
+func (r *Reader) IsRetryable(err error) bool {
+	return IsRetryable(err)
+}
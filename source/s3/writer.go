@@ -12,14 +12,38 @@ import (
 	"github.com/hexbee-net/errors"
 )
 
+const errAborted = errors.Error("upload aborted")
+
+// WriterOptions contains the configuration of the upload of a Writer.
+type WriterOptions struct {
+	// PartSize is the size of the parts of the multipart upload.
+	// Defaults to s3manager.DefaultUploadPartSize.
+	PartSize int64
+	// Concurrency is the number of parts uploaded in parallel.
+	// Defaults to s3manager.DefaultUploadConcurrency.
+	Concurrency int
+	// ServerSideEncryption is the algorithm used to encrypt the object (AES256, aws:kms).
+	ServerSideEncryption string
+	// SSEKMSKeyID is the ID of the KMS key used to encrypt the object.
+	SSEKMSKeyID string
+	// ContentType is the MIME type of the object.
+	ContentType string
+	// Metadata is stored with the object as x-amz-meta-* headers.
+	Metadata map[string]string
+	// UploaderOptions are applied to the uploader after the options above.
+	UploaderOptions []func(*s3manager.Uploader)
+}
+
 type Writer struct {
 	file
 
-	writeDone       chan error
-	pipeReader      *io.PipeReader
-	pipeWriter      *io.PipeWriter
-	uploader        *s3manager.Uploader
-	uploaderOptions []func(*s3manager.Uploader)
+	cancel     context.CancelFunc
+	writeDone  chan error
+	pipeReader *io.PipeReader
+	pipeWriter *io.PipeWriter
+	uploader   *s3manager.Uploader
+	options    WriterOptions
+	aborted    bool
 }
 
 // NewWriter creates an S3 Writer.
@@ -29,6 +53,13 @@ func NewWriter(ctx context.Context, bucket, key string, uploaderOptions []func(*
 
 // NewWriterWithClient is the same as NewWriter but allows passing your own S3 client.
 func NewWriterWithClient(ctx context.Context, s3Client s3iface.S3API, bucket, key string, uploaderOptions []func(*s3manager.Uploader)) (*Writer, error) {
+	return NewWriterWithOptions(ctx, s3Client, bucket, key, WriterOptions{UploaderOptions: uploaderOptions})
+}
+
+// NewWriterWithOptions is the same as NewWriterWithClient but allows configuring the upload.
+func NewWriterWithOptions(ctx context.Context, s3Client s3iface.S3API, bucket, key string, options WriterOptions) (*Writer, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
 	writer := Writer{
 		file: file{
 			ctx:        ctx,
@@ -37,35 +68,35 @@ func NewWriterWithClient(ctx context.Context, s3Client s3iface.S3API, bucket, ke
 			Key:        key,
 		},
 
-		writeDone:       make(chan error),
-		uploaderOptions: uploaderOptions,
+		cancel:    cancel,
+		writeDone: make(chan error, 1),
+		options:   options,
 	}
 
 	writer.pipeReader, writer.pipeWriter = io.Pipe()
-	writer.uploader = s3manager.NewUploaderWithClient(writer.client, writer.uploaderOptions...)
-
-	uploadParams := &s3manager.UploadInput{
-		Bucket: aws.String(writer.BucketName),
-		Key:    aws.String(writer.Key),
-		Body:   writer.pipeReader,
-	}
+	writer.uploader = s3manager.NewUploaderWithClient(writer.client, writer.uploaderOptions()...)
 
 	go func(uploader *s3manager.Uploader, params *s3manager.UploadInput, done chan<- error) {
 		defer close(done)
 
-		// upload data and signal done when complete
+		// upload data and signal done when complete.
+		// On failure, the uploader aborts the multipart upload so no object is created.
 		_, err := uploader.UploadWithContext(writer.ctx, params)
 		if err != nil {
-			_ = writer.pipeWriter.CloseWithError(err)
+			_ = writer.pipeReader.CloseWithError(err)
 		}
 
 		done <- err
-	}(writer.uploader, uploadParams, writer.writeDone)
+	}(writer.uploader, writer.uploadInput(), writer.writeDone)
 
 	return &writer, nil
 }
 
 func (w *Writer) Write(p []byte) (n int, err error) {
+	if w.aborted {
+		return 0, errors.WithStack(errAborted)
+	}
+
 	bytesWritten, err := w.pipeWriter.Write(p)
 	if err != nil {
 		_ = w.pipeWriter.CloseWithError(err)
@@ -77,6 +108,10 @@ func (w *Writer) Write(p []byte) (n int, err error) {
 }
 
 func (w *Writer) Close() (err error) {
+	if w.aborted {
+		return errors.WithStack(errAborted)
+	}
+
 	if w.pipeWriter != nil {
 		if closeErr := w.pipeWriter.Close(); closeErr != nil {
 			return errors.Wrap(closeErr, "failed to close pipe writer")
@@ -88,5 +123,74 @@ func (w *Writer) Close() (err error) {
 		err = <-w.writeDone
 	}
 
+	w.cancel()
+
 	return err
 }
+
+// Abort cancels the upload, so that no object is created.
+// The parts already uploaded are deleted. err is the cause reported to the uploader,
+// it defaults to an "upload aborted" error.
+func (w *Writer) Abort(err error) error {
+	if w.aborted {
+		return nil
+	}
+
+	w.aborted = true
+
+	if err == nil {
+		err = errAborted
+	}
+
+	_ = w.pipeWriter.CloseWithError(err)
+	w.cancel()
+
+	// wait for the uploader to clean up the multipart upload.
+	<-w.writeDone
+
+	return nil
+}
+
+func (w *Writer) uploaderOptions() []func(*s3manager.Uploader) {
+	opts := []func(*s3manager.Uploader){
+		func(u *s3manager.Uploader) {
+			if w.options.PartSize > 0 {
+				u.PartSize = w.options.PartSize
+			}
+
+			if w.options.Concurrency > 0 {
+				u.Concurrency = w.options.Concurrency
+			}
+
+			u.LeavePartsOnError = false
+		},
+	}
+
+	return append(opts, w.options.UploaderOptions...)
+}
+
+func (w *Writer) uploadInput() *s3manager.UploadInput {
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(w.BucketName),
+		Key:    aws.String(w.Key),
+		Body:   w.pipeReader,
+	}
+
+	if w.options.ServerSideEncryption != "" {
+		input.ServerSideEncryption = aws.String(w.options.ServerSideEncryption)
+	}
+
+	if w.options.SSEKMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(w.options.SSEKMSKeyID)
+	}
+
+	if w.options.ContentType != "" {
+		input.ContentType = aws.String(w.options.ContentType)
+	}
+
+	if len(w.options.Metadata) > 0 {
+		input.Metadata = aws.StringMap(w.options.Metadata)
+	}
+
+	return input
+}
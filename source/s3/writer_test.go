@@ -0,0 +1,136 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	t.Run("Close", TestWriter_Close)
+	t.Run("Abort", TestWriter_Abort)
+	t.Run("AbortMultipart", TestWriter_AbortMultipart)
+}
+
+// fakeS3 records the calls issued by the uploader.
+type fakeS3 struct {
+	s3iface.S3API
+
+	mu        sync.Mutex
+	calls     []string
+	putObject *s3.PutObjectInput
+}
+
+func (f *fakeS3) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeS3) PutObjectRequest(input *s3.PutObjectInput) (*request.Request, *s3.PutObjectOutput) {
+	output := &s3.PutObjectOutput{}
+	req := request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{Name: "PutObject"}, input, output)
+
+	req.Handlers.Send.PushBack(func(*request.Request) {
+		_, _ = io.Copy(ioutil.Discard, input.Body)
+
+		f.record("PutObject")
+		f.putObject = input
+	})
+
+	return req, output
+}
+
+func (f *fakeS3) CreateMultipartUploadWithContext(aws.Context, *s3.CreateMultipartUploadInput, ...request.Option) (*s3.CreateMultipartUploadOutput, error) {
+	f.record("CreateMultipartUpload")
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload")}, nil
+}
+
+func (f *fakeS3) UploadPartWithContext(_ aws.Context, input *s3.UploadPartInput, _ ...request.Option) (*s3.UploadPartOutput, error) {
+	_, _ = io.Copy(ioutil.Discard, input.Body)
+
+	f.record("UploadPart")
+
+	return &s3.UploadPartOutput{ETag: aws.String("etag")}, nil
+}
+
+func (f *fakeS3) CompleteMultipartUploadWithContext(aws.Context, *s3.CompleteMultipartUploadInput, ...request.Option) (*s3.CompleteMultipartUploadOutput, error) {
+	f.record("CompleteMultipartUpload")
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeS3) AbortMultipartUploadWithContext(aws.Context, *s3.AbortMultipartUploadInput, ...request.Option) (*s3.AbortMultipartUploadOutput, error) {
+	f.record("AbortMultipartUpload")
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func TestWriter_Close(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeS3{}
+
+	w, err := NewWriterWithOptions(context.Background(), client, "bucket", "key", WriterOptions{
+		ServerSideEncryption: "AES256",
+		Metadata:             map[string]string{"origin": "test"},
+	})
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"PutObject"}, client.calls)
+	assert.Equal(t, "AES256", aws.StringValue(client.putObject.ServerSideEncryption))
+	assert.Equal(t, "test", aws.StringValue(client.putObject.Metadata["origin"]))
+}
+
+func TestWriter_Abort(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeS3{}
+
+	w, err := NewWriterWithClient(context.Background(), client, "bucket", "key", nil)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("PAR1"))
+	require.NoError(t, err)
+	require.NoError(t, w.Abort(nil))
+	assert.Error(t, w.Close())
+
+	_, err = w.Write([]byte("PAR1"))
+	assert.Error(t, err)
+
+	assert.Empty(t, client.calls)
+}
+
+func TestWriter_AbortMultipart(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeS3{}
+
+	w, err := NewWriterWithOptions(context.Background(), client, "bucket", "key", WriterOptions{
+		PartSize:    s3manager.MinUploadPartSize,
+		Concurrency: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = w.Write(make([]byte, 2*s3manager.MinUploadPartSize))
+	require.NoError(t, err)
+	require.NoError(t, w.Abort(nil))
+
+	assert.Contains(t, client.calls, "CreateMultipartUpload")
+	assert.Contains(t, client.calls, "AbortMultipartUpload")
+	assert.NotContains(t, client.calls, "CompleteMultipartUpload")
+}
@@ -6,3 +6,9 @@ type Writer interface {
 	io.Writer
 	io.Closer
 }
+
+// Aborter is implemented by writers that can discard the data written so far
+// instead of creating the target object on Close.
+type Aborter interface {
+	Abort(err error) error
+}
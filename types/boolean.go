@@ -3,6 +3,7 @@ package types
 import (
 	"io"
 
+	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/encoding"
 )
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 const (
@@ -56,7 +57,7 @@ func (e ByteArrayPlainEncoder) writeBytes(data []byte) error {
 		}
 	} else if len(data) != l {
 		return errors.WithFields(
-			errors.New("byte array has invalid length"),
+			errs.New(errs.ErrSchemaMismatch, "byte array has invalid length"),
 			errors.Fields{
 				"expected": l,
 				"actual":   len(data),
@@ -99,7 +100,7 @@ func (d ByteArrayPlainDecoder) next() ([]byte, error) {
 		}
 
 		if l < 0 {
-			return nil, errors.New("bytearray/plain: len is negative")
+			return nil, errs.New(errs.ErrCorruptPage, "bytearray/plain: len is negative")
 		}
 	}
 
@@ -300,7 +301,7 @@ func (d *ByteArrayDeltaDecoder) Init(reader io.Reader) error {
 
 	if len(d.prefixLens) != len(d.suffixDecoder.lens) {
 		return errors.WithFields(
-			errors.New("bytearray/delta: different number of suffixes and prefixes"),
+			errs.New(errs.ErrCorruptPage, "bytearray/delta: different number of suffixes and prefixes"),
 			errors.Fields{
 				"prefix": len(d.prefixLens),
 				"suffix": len(d.suffixDecoder.lens),
@@ -328,7 +329,7 @@ func (d *ByteArrayDeltaDecoder) DecodeValues(dest []interface{}) (count int, err
 		if len(d.previousValue) < prefixLen {
 			// prevent panic from invalid input
 			return 0, errors.WithFields(
-				errors.New("invalid prefix len in the stream"),
+				errs.New(errs.ErrCorruptPage, "invalid prefix len in the stream"),
 				errors.Fields{
 					"expected": prefixLen,
 					"actual":   len(d.previousValue),
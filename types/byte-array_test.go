@@ -0,0 +1,25 @@
+package types
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/hexbee-net/parquet/errs"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteArrayPlainEncoder(t *testing.T) {
+	t.Run("EncodeValues_InvalidLength", TestByteArrayPlainEncoder_EncodeValues_InvalidLength)
+}
+
+func TestByteArrayPlainEncoder_EncodeValues_InvalidLength(t *testing.T) {
+	t.Parallel()
+
+	e := ByteArrayPlainEncoder{writer: memory.NewWriter(nil), Length: 4}
+
+	assert.NoError(t, e.EncodeValues([]interface{}{[]byte("abcd")}))
+
+	err := e.EncodeValues([]interface{}{[]byte("abc")})
+	assert.True(t, stderrors.Is(err, errs.ErrSchemaMismatch))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hexbee-net/errors"
 	"github.com/hexbee-net/parquet/datastore"
 	"github.com/hexbee-net/parquet/encoding"
+	"github.com/hexbee-net/parquet/errs"
 )
 
 // Encoder /////////////////////////////
@@ -23,12 +24,13 @@ func (e *DictEncoder) Init(writer io.Writer) error {
 	return nil
 }
 
+// EncodeValues adds the values to the dictionary. When the values not in the dictionary
+// yet would make it exceed its MaxSize, it returns datastore.ErrDictionaryFull and none of
+// the values is added. The dictionary encoding can't go on: the caller should write the
+// dictionary page and the data page of the values added so far, and encode these values,
+// and the following ones of the column chunk, with the PLAIN encoding.
 func (e *DictEncoder) EncodeValues(values []interface{}) error {
-	for i := range values {
-		e.AddValue(values[i], 0) // size is not important here
-	}
-
-	return nil
+	return e.AddValues(values)
 }
 
 func (e *DictEncoder) Close() error {
@@ -74,7 +76,7 @@ func (d *DictDecoder) Init(reader io.Reader) error {
 
 	if w < 0 || w > 32 {
 		return errors.WithFields(
-			errors.New("invalid bit-width"),
+			errs.New(errs.ErrCorruptPage, "invalid bit-width"),
 			errors.Fields{
 				"bit-width": w,
 			})
@@ -85,12 +87,12 @@ func (d *DictDecoder) Init(reader io.Reader) error {
 		return d.keys.Init(reader)
 	}
 
-	return errors.New("bit-width zero with non-empty dictionary")
+	return errs.New(errs.ErrCorruptPage, "bit-width zero with non-empty dictionary")
 }
 
 func (d *DictDecoder) DecodeValues(dest []interface{}) (count int, err error) {
 	if d.keys == nil {
-		return 0, errors.New("no value is inside dictionary")
+		return 0, errs.New(errs.ErrCorruptPage, "no value is inside dictionary")
 	}
 
 	size := int32(len(d.Values))
@@ -103,7 +105,7 @@ func (d *DictDecoder) DecodeValues(dest []interface{}) (count int, err error) {
 
 		if key >= size {
 			return 0, errors.WithFields(
-				errors.New("invalid index"),
+				errs.New(errs.ErrCorruptPage, "invalid index"),
 				errors.Fields{
 					"index":        key,
 					"values-count": size,
@@ -0,0 +1,33 @@
+package types
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/hexbee-net/parquet/datastore"
+	"github.com/hexbee-net/parquet/source/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictEncoder(t *testing.T) {
+	t.Run("EncodeValues_Full", TestDictEncoder_EncodeValues_Full)
+}
+
+func TestDictEncoder_EncodeValues_Full(t *testing.T) {
+	t.Parallel()
+
+	e := &DictEncoder{}
+	require.NoError(t, e.Init(memory.NewWriter(nil)))
+	e.MaxSize = 2 * 8
+
+	require.NoError(t, e.EncodeValues([]interface{}{int64(1), int64(2), int64(1)}))
+
+	// The batch is rejected as a whole, the values can be encoded with PLAIN instead.
+	err := e.EncodeValues([]interface{}{int64(2), int64(3)})
+	assert.True(t, stderrors.Is(err, datastore.ErrDictionaryFull))
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, e.Values)
+	assert.Equal(t, []int32{0, 1, 0}, e.Data)
+
+	assert.NoError(t, e.Close())
+}